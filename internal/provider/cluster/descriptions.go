@@ -0,0 +1,42 @@
+package cluster
+
+// descriptions for cluster resources
+const (
+	DESC_RESOURCES = "The cluster resources inventory (guests, " +
+		"storages, nodes, sdn zones and pools), sorted by id."
+	DESC_RESOURCES_TYPE = "Only list the resources of this type.\n" +
+		"Values: qemu | lxc | storage | node | sdn | pool"
+	DESC_RESOURCES_TAG        = "Only list the guests having this tag."
+	DESC_RESOURCES_POOL       = "Only list the resources of this pool."
+	DESC_RESOURCES_NODE       = "Only list the resources of this node."
+	DESC_RESOURCES_NAME_REGEX = "Only list the resources whose name " +
+		"matches this regular expression. Storages and sdn zones " +
+		"are matched by their storage and zone name."
+	DESC_RESOURCES_RESOURCES = "The matching resources."
+	DESC_RESOURCES_ID        = "The resource id, i.e. lxc/100 or storage/pve1/local."
+	DESC_RESOURCES_VMID      = "The guest vmid."
+	DESC_RESOURCES_NAME      = "The resource name."
+	DESC_RESOURCES_STATUS    = "The resource status, i.e. running, online or available."
+	DESC_RESOURCES_TAGS      = "The guest tags."
+	DESC_RESOURCES_TEMPLATE  = "Whether the guest is a template."
+	DESC_RESOURCES_CPU       = "The cpu usage, from 0 to 1."
+	DESC_RESOURCES_MAXCPU    = "The number of cpus."
+	DESC_RESOURCES_MEM       = "The used memory in bytes."
+	DESC_RESOURCES_MAXMEM    = "The total memory in bytes."
+	DESC_RESOURCES_DISK      = "The used disk space in bytes."
+	DESC_RESOURCES_MAXDISK   = "The total disk space in bytes."
+	DESC_RESOURCES_UPTIME    = "The uptime in seconds."
+)
+
+// descriptions for the next vmid data source
+const (
+	DESC_NEXT_VMID = "The lowest vmid that is not used by any guest " +
+		"of the cluster. The vmid is not reserved, the resources " +
+		"that allocate a vmid already pick a free one within the " +
+		"provider vmid_range."
+	DESC_NEXT_VMID_MIN = "The lowest vmid to return, defaults to " +
+		"the provider vmid_range min."
+	DESC_NEXT_VMID_MAX = "The highest vmid to return, defaults to " +
+		"the provider vmid_range max."
+	DESC_NEXT_VMID_ID = "The next free vmid."
+)
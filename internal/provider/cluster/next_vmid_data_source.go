@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &nextVMIDDataSource{}
+	_ datasource.DataSourceWithConfigure = &nextVMIDDataSource{}
+)
+
+// NewNextVMIDDataSource is a helper function to simplify the provider implementation.
+func NewNextVMIDDataSource() datasource.DataSource {
+	return &nextVMIDDataSource{}
+}
+
+// nextVMIDDataSource is the data source implementation.
+type nextVMIDDataSource struct {
+	client *proxmox.Client
+}
+
+// nextVMIDDataSourceModel describes the data source data model.
+type nextVMIDDataSourceModel struct {
+	Cluster types.String `tfsdk:"cluster"`
+	Min     types.Int64  `tfsdk:"min"`
+	Max     types.Int64  `tfsdk:"max"`
+	ID      types.Int64  `tfsdk:"id"`
+}
+
+// Metadata returns the data source type name.
+func (d *nextVMIDDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "next_vmid"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *nextVMIDDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: DESC_NEXT_VMID,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"min": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_NEXT_VMID_MIN,
+				Validators: []validator.Int64{
+					validators.Between(proxmox.VMIDMin, proxmox.VMIDMax),
+				},
+			},
+			"max": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_NEXT_VMID_MAX,
+				Validators: []validator.Int64{
+					validators.Between(proxmox.VMIDMin, proxmox.VMIDMax),
+				},
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: DESC_NEXT_VMID_ID,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *nextVMIDDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state nextVMIDDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmidRange := client.VMIDRange()
+	if !state.Min.IsNull() {
+		vmidRange.Min = int(state.Min.ValueInt64())
+	}
+	if !state.Max.IsNull() {
+		vmidRange.Max = int(state.Max.ValueInt64())
+	}
+	if err := vmidRange.Validate(); err != nil {
+		resp.Diagnostics.AddError("Invalid VMID Range", err.Error())
+		return
+	}
+
+	vmid, err := client.NextVMID(ctx, vmidRange)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox Next VMID",
+			err.Error(),
+		)
+		return
+	}
+	state.ID = types.Int64Value(int64(vmid))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nextVMIDDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,282 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &resourcesDataSource{}
+	_ datasource.DataSourceWithConfigure = &resourcesDataSource{}
+)
+
+// resourceTypeFilters maps the resource types to the
+// /cluster/resources type filter.
+var resourceTypeFilters = map[string]string{
+	"qemu":    "vm",
+	"lxc":     "vm",
+	"storage": "storage",
+	"node":    "node",
+	"sdn":     "sdn",
+	"pool":    "",
+}
+
+// NewResourcesDataSource is a helper function to simplify the provider implementation.
+func NewResourcesDataSource() datasource.DataSource {
+	return &resourcesDataSource{}
+}
+
+// resourcesDataSource is the data source implementation.
+type resourcesDataSource struct {
+	client *proxmox.Client
+}
+
+// resourcesDataSourceModel describes the data source data model.
+type resourcesDataSourceModel struct {
+	Cluster   types.String    `tfsdk:"cluster"`
+	Type      types.String    `tfsdk:"type"`
+	Tag       types.String    `tfsdk:"tag"`
+	Pool      types.String    `tfsdk:"pool"`
+	Node      types.String    `tfsdk:"node"`
+	NameRegex types.String    `tfsdk:"name_regex"`
+	Resources []resourceModel `tfsdk:"resources"`
+}
+
+// resourceModel describes a cluster resource.
+type resourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	Type     types.String  `tfsdk:"type"`
+	VMID     types.Int64   `tfsdk:"vmid"`
+	Name     types.String  `tfsdk:"name"`
+	Node     types.String  `tfsdk:"node"`
+	Status   types.String  `tfsdk:"status"`
+	Tags     types.List    `tfsdk:"tags"`
+	Pool     types.String  `tfsdk:"pool"`
+	Template types.Bool    `tfsdk:"template"`
+	CPU      types.Float64 `tfsdk:"cpu"`
+	MaxCPU   types.Float64 `tfsdk:"maxcpu"`
+	Mem      types.Int64   `tfsdk:"mem"`
+	MaxMem   types.Int64   `tfsdk:"maxmem"`
+	Disk     types.Int64   `tfsdk:"disk"`
+	MaxDisk  types.Int64   `tfsdk:"maxdisk"`
+	Uptime   types.Int64   `tfsdk:"uptime"`
+}
+
+// Metadata returns the data source type name.
+func (d *resourcesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "cluster_resources"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *resourcesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resourceSchema := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_ID},
+			"type":     schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_TYPE},
+			"vmid":     schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_VMID},
+			"name":     schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_NAME},
+			"node":     schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_NODE},
+			"status":   schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_STATUS},
+			"tags":     schema.ListAttribute{ElementType: types.StringType, Computed: true, Description: DESC_RESOURCES_TAGS},
+			"pool":     schema.StringAttribute{Computed: true, Description: DESC_RESOURCES_POOL},
+			"template": schema.BoolAttribute{Computed: true, Description: DESC_RESOURCES_TEMPLATE},
+			"cpu":      schema.Float64Attribute{Computed: true, Description: DESC_RESOURCES_CPU},
+			"maxcpu":   schema.Float64Attribute{Computed: true, Description: DESC_RESOURCES_MAXCPU},
+			"mem":      schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_MEM},
+			"maxmem":   schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_MAXMEM},
+			"disk":     schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_DISK},
+			"maxdisk":  schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_MAXDISK},
+			"uptime":   schema.Int64Attribute{Computed: true, Description: DESC_RESOURCES_UPTIME},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: DESC_RESOURCES,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_RESOURCES_TYPE,
+				Validators: []validator.String{
+					validators.OneOf("qemu", "lxc", "storage", "node", "sdn", "pool"),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_RESOURCES_TAG,
+			},
+			"pool": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_RESOURCES_POOL,
+			},
+			"node": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_RESOURCES_NODE,
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_RESOURCES_NAME_REGEX,
+			},
+			"resources": schema.ListNestedAttribute{
+				NestedObject: resourceSchema,
+				Computed:     true,
+				Description:  DESC_RESOURCES_RESOURCES,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *resourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state resourcesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		re, err := regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+			return
+		}
+		nameRegex = re
+	}
+
+	tflog.Info(ctx, "reading cluster resources", map[string]any{"type": state.Type.ValueString()})
+
+	resources, err := client.GetClusterResources(ctx, resourceTypeFilters[state.Type.ValueString()])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox Cluster Resources",
+			err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].ID < resources[j].ID
+	})
+
+	state.Resources = []resourceModel{}
+	for _, r := range resources {
+		name := resourceName(r)
+		tags := splitTags(r.Tags)
+
+		switch {
+		case !state.Type.IsNull() && r.Type != state.Type.ValueString():
+			continue
+		case !state.Tag.IsNull() && !slices.Contains(tags, state.Tag.ValueString()):
+			continue
+		case !state.Pool.IsNull() && r.Pool != state.Pool.ValueString():
+			continue
+		case !state.Node.IsNull() && r.Node != state.Node.ValueString():
+			continue
+		case nameRegex != nil && !nameRegex.MatchString(name):
+			continue
+		}
+
+		tagValues := []attr.Value{}
+		for _, tag := range tags {
+			tagValues = append(tagValues, types.StringValue(tag))
+		}
+
+		resource := resourceModel{
+			ID:       types.StringValue(r.ID),
+			Type:     types.StringValue(r.Type),
+			VMID:     types.Int64Null(),
+			Name:     types.StringValue(name),
+			Node:     optional.String(r.Node),
+			Status:   optional.String(r.Status),
+			Tags:     types.ListValueMust(types.StringType, tagValues),
+			Pool:     optional.String(r.Pool),
+			Template: types.BoolValue(r.Template == 1),
+			CPU:      types.Float64Value(r.CPU),
+			MaxCPU:   types.Float64Value(r.MaxCPU),
+			Mem:      types.Int64Value(r.Mem),
+			MaxMem:   types.Int64Value(r.MaxMem),
+			Disk:     types.Int64Value(r.Disk),
+			MaxDisk:  types.Int64Value(r.MaxDisk),
+			Uptime:   types.Int64Value(r.Uptime),
+		}
+		if r.VMID != 0 {
+			resource.VMID = types.Int64Value(int64(r.VMID))
+		}
+
+		state.Resources = append(state.Resources, resource)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *resourcesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// resourceName returns the name of a cluster resource, only
+// guests have a name field, the other types are named by their id.
+func resourceName(r proxmox.ClusterResource) string {
+	switch r.Type {
+	case "qemu", "lxc":
+		return r.Name
+	case "storage":
+		return r.Storage
+	case "node":
+		return r.Node
+	case "sdn":
+		return r.SDN
+	case "pool":
+		return r.Pool
+	}
+	return r.ID
+}
+
+// splitTags parses the proxmox tags, they are separated by
+// semicolons but commas and spaces are accepted as well.
+func splitTags(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+}
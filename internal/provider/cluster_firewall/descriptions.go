@@ -0,0 +1,45 @@
+package clusterfirewall
+
+// descriptions for options
+const (
+	DESC_OPTIONS = "Datacenter firewall options.\n" +
+		"The datacenter firewall must be enabled for any " +
+		"node or guest firewall rule to take effect.\n" +
+		"Note: destroying this resource resets the options " +
+		"to the proxmox defaults, which disables the firewall."
+	DESC_OPTIONS_ENABLE = "Enable or disable the firewall cluster wide.\n" +
+		"When enabled, 'policy_in' must be explicitly set."
+	DESC_OPTIONS_EBTABLES  = "Enable ebtables rules cluster wide."
+	DESC_OPTIONS_POLICY_IN = "Input policy. Applied to incoming " +
+		"traffic that does not match any rule.\n" +
+		"Values: ACCEPT | REJECT | DROP"
+	DESC_OPTIONS_POLICY_OUT = "Output policy. Applied to outgoing " +
+		"traffic that does not match any rule.\n" +
+		"Values: ACCEPT | REJECT | DROP"
+	DESC_OPTIONS_POLICY_FWD = "Forward policy. Applied to forwarded " +
+		"traffic that does not match any rule (requires pve >= 8.2). " +
+		"It is only sent to proxmox when set.\n" +
+		"Values: ACCEPT | DROP"
+	DESC_OPTIONS_LOG_RATELIMIT        = "Log ratelimiting settings."
+	DESC_OPTIONS_LOG_RATELIMIT_ENABLE = "Enable or disable log " +
+		"rate limiting."
+	DESC_OPTIONS_LOG_RATELIMIT_RATE = "Frequency with which the burst " +
+		"bucket gets refilled.\n" +
+		`Format: [1-9][0-9]*\/(second|minute|hour|day)`
+	DESC_OPTIONS_LOG_RATELIMIT_BURST = "Initial burst of packages " +
+		"which will always get logged before the rate is applied.\n" +
+		"Values: 0 - 100"
+)
+
+// proxmox defaults for the datacenter firewall options,
+// applied when an option is not present in the config.
+const (
+	DFLT_OPTIONS_ENABLE               = false
+	DFLT_OPTIONS_EBTABLES             = true
+	DFLT_OPTIONS_POLICY_IN            = "DROP"
+	DFLT_OPTIONS_POLICY_OUT           = "ACCEPT"
+	DFLT_OPTIONS_POLICY_FWD           = "ACCEPT"
+	DFLT_OPTIONS_LOG_RATELIMIT_ENABLE = true
+	DFLT_OPTIONS_LOG_RATELIMIT_RATE   = "1/second"
+	DFLT_OPTIONS_LOG_RATELIMIT_BURST  = 5
+)
@@ -0,0 +1,521 @@
+package clusterfirewall
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OptionsResource{}
+var _ resource.ResourceWithImportState = &OptionsResource{}
+var _ resource.ResourceWithModifyPlan = &OptionsResource{}
+var _ resource.ResourceWithValidateConfig = &OptionsResource{}
+
+func NewOptionsResource() resource.Resource {
+	return &OptionsResource{}
+}
+
+// OptionsResource defines the resource implementation.
+type OptionsResource struct {
+	client *proxmox.Client
+}
+
+// OptionsResourceModel describes the resource data model.
+type OptionsResourceModel struct {
+	Cluster       types.String `tfsdk:"cluster"`
+	Enable        types.Bool   `tfsdk:"enable"`
+	Ebtables      types.Bool   `tfsdk:"ebtables"`
+	PolicyIn      types.String `tfsdk:"policy_in"`
+	PolicyOut     types.String `tfsdk:"policy_out"`
+	PolicyForward types.String `tfsdk:"policy_forward"`
+	LogRatelimit  types.Object `tfsdk:"log_ratelimit"`
+}
+
+// LogRatelimitModel describes the log_ratelimit data model.
+type LogRatelimitModel struct {
+	Enable types.Bool   `tfsdk:"enable"`
+	Rate   types.String `tfsdk:"rate"`
+	Burst  types.Int64  `tfsdk:"burst"`
+}
+
+var logRatelimitAttrTypes = map[string]attr.Type{
+	"enable": types.BoolType,
+	"rate":   types.StringType,
+	"burst":  types.Int64Type,
+}
+
+func (m *LogRatelimitModel) LoadFromObject(ctx context.Context, obj types.Object) {
+	obj.As(ctx, m, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+}
+
+// LoadFromPVE parses the proxmox log_ratelimit property string,
+// i.e. "enable=1,rate=1/second,burst=5".
+func (m *LogRatelimitModel) LoadFromPVE(value string) error {
+	m.Enable = types.BoolValue(DFLT_OPTIONS_LOG_RATELIMIT_ENABLE)
+	m.Rate = types.StringValue(DFLT_OPTIONS_LOG_RATELIMIT_RATE)
+	m.Burst = types.Int64Value(DFLT_OPTIONS_LOG_RATELIMIT_BURST)
+
+	if value == "" {
+		return nil
+	}
+
+	for _, kv := range strings.Split(value, ",") {
+		k, v, found := strings.Cut(kv, "=")
+		// enable is the default key of the property string
+		if !found {
+			k, v = "enable", k
+		}
+
+		switch k {
+		case "enable":
+			m.Enable = types.BoolValue(v == "1")
+		case "rate":
+			m.Rate = types.StringValue(v)
+		case "burst":
+			burst, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid log_ratelimit burst value %q", v)
+			}
+			m.Burst = types.Int64Value(burst)
+		default:
+			return fmt.Errorf("unexpected log_ratelimit key %q", k)
+		}
+	}
+
+	return nil
+}
+
+func (m LogRatelimitModel) ToObject() types.Object {
+	object, _ := types.ObjectValueFrom(context.TODO(), logRatelimitAttrTypes, m)
+	return object
+}
+
+// ToPVE formats the model as a proxmox property string.
+func (m LogRatelimitModel) ToPVE() string {
+	enable := 0
+	if m.Enable.ValueBool() {
+		enable = 1
+	}
+
+	return fmt.Sprintf("enable=%d,rate=%s,burst=%d", enable, m.Rate.ValueString(), m.Burst.ValueInt64())
+}
+
+func (r *OptionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "cluster_firewall_options"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *OptionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	policies := []string{"ACCEPT", "REJECT", "DROP"}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cluster firewall options resource",
+		Description:         DESC_OPTIONS,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"enable": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_OPTIONS_ENABLE),
+				Description: DESC_OPTIONS_ENABLE,
+			},
+			"ebtables": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_OPTIONS_EBTABLES),
+				Description: DESC_OPTIONS_EBTABLES,
+			},
+			"policy_in": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString(DFLT_OPTIONS_POLICY_IN),
+				Description: DESC_OPTIONS_POLICY_IN,
+				Validators: []validator.String{
+					validators.OneOf(policies...),
+				},
+			},
+			"policy_out": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString(DFLT_OPTIONS_POLICY_OUT),
+				Description: DESC_OPTIONS_POLICY_OUT,
+				Validators: []validator.String{
+					validators.OneOf(policies...),
+				},
+			},
+			"policy_forward": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_OPTIONS_POLICY_FWD,
+				Validators: []validator.String{
+					validators.OneOf("ACCEPT", "DROP"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"log_ratelimit": schema.SingleNestedAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_OPTIONS_LOG_RATELIMIT,
+				Default: objectdefault.StaticValue(types.ObjectValueMust(
+					logRatelimitAttrTypes,
+					map[string]attr.Value{
+						"enable": types.BoolValue(DFLT_OPTIONS_LOG_RATELIMIT_ENABLE),
+						"rate":   types.StringValue(DFLT_OPTIONS_LOG_RATELIMIT_RATE),
+						"burst":  types.Int64Value(DFLT_OPTIONS_LOG_RATELIMIT_BURST),
+					},
+				)),
+				Attributes: map[string]schema.Attribute{
+					"enable": schema.BoolAttribute{
+						Computed:    true,
+						Optional:    true,
+						Default:     booldefault.StaticBool(DFLT_OPTIONS_LOG_RATELIMIT_ENABLE),
+						Description: DESC_OPTIONS_LOG_RATELIMIT_ENABLE,
+					},
+					"rate": schema.StringAttribute{
+						Computed:    true,
+						Optional:    true,
+						Default:     stringdefault.StaticString(DFLT_OPTIONS_LOG_RATELIMIT_RATE),
+						Description: DESC_OPTIONS_LOG_RATELIMIT_RATE,
+						Validators: []validator.String{
+							validators.Regex(
+								regexp.MustCompile(`^[1-9][0-9]*/(second|minute|hour|day)$`),
+								"value must be formatted as '<number>/<second|minute|hour|day>'",
+							),
+						},
+					},
+					"burst": schema.Int64Attribute{
+						Computed:    true,
+						Optional:    true,
+						Default:     int64default.StaticInt64(DFLT_OPTIONS_LOG_RATELIMIT_BURST),
+						Description: DESC_OPTIONS_LOG_RATELIMIT_BURST,
+						Validators: []validator.Int64{
+							validators.Between(0, 100),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *OptionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig prevents enabling the datacenter firewall
+// without an explicit input policy, as a wrong default policy
+// can lock everyone out of the cluster.
+func (r *OptionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data OptionsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Enable.IsUnknown() || !data.Enable.ValueBool() {
+		return
+	}
+
+	if data.PolicyIn.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_in"),
+			"Missing Cluster Firewall Input Policy",
+			fmt.Sprintf("The datacenter firewall is being enabled without an explicit input policy, "+
+				"which means the proxmox default (%s) would be applied to every node. "+
+				"Set policy_in explicitly to confirm the desired behavior.", DFLT_OPTIONS_POLICY_IN),
+		)
+		return
+	}
+
+	if policy := data.PolicyIn.ValueString(); policy == "DROP" || policy == "REJECT" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("policy_in"),
+			"Restrictive Cluster Firewall Input Policy",
+			fmt.Sprintf("Incoming traffic that does not match any rule will be handled with %s on every node. "+
+				"Make sure the management ip set or your firewall rules allow access to the "+
+				"proxmox api (8006) and ssh (22) before applying.", policy),
+		)
+	}
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *OptionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	preflight.Require(client, &resp.Diagnostics, "Sys.Modify", "/")
+}
+
+func (r *OptionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set cluster firewall options, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster firewall options, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OptionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OptionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster firewall options, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update cluster firewall options, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster firewall options, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete resets every option present in the cluster firewall
+// config back to the proxmox defaults.
+func (r *OptionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var cluster types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(clusterattr.Name), &cluster)...)
+	client := clusterattr.Client(ctx, r.client, cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := client.GetClusterFirewallOptions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster firewall options, got error: %s", err))
+		return
+	}
+
+	apiReq := proxmox.UpdateClusterFirewallOptionsRequest{
+		Digest: remote.Digest,
+	}
+	if remote.Enable != nil {
+		apiReq.Delete = append(apiReq.Delete, "enable")
+	}
+	if remote.Ebtables != nil {
+		apiReq.Delete = append(apiReq.Delete, "ebtables")
+	}
+	if remote.LogRatelimit != nil {
+		apiReq.Delete = append(apiReq.Delete, "log_ratelimit")
+	}
+	if remote.PolicyIn != nil {
+		apiReq.Delete = append(apiReq.Delete, "policy_in")
+	}
+	if remote.PolicyOut != nil {
+		apiReq.Delete = append(apiReq.Delete, "policy_out")
+	}
+	if remote.PolicyForward != nil {
+		apiReq.Delete = append(apiReq.Delete, "policy_forward")
+	}
+
+	if len(apiReq.Delete) == 0 {
+		return
+	}
+
+	if err := client.UpdateClusterFirewallOptions(ctx, apiReq); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset cluster firewall options, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// ImportState accepts any id, as the cluster firewall options
+// are a singleton.
+func (r *OptionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// the identifier is only used to select the cluster, i.e.
+	// cluster:options.
+	cluster, _ := clusterattr.ImportID(req.ID)
+	state := OptionsResourceModel{
+		Cluster:      cluster,
+		LogRatelimit: types.ObjectNull(logRatelimitAttrTypes),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// apply sends the planned options to proxmox. The config
+// digest is sent along to prevent overwriting changes made
+// in between.
+func (r *OptionsResource) apply(ctx context.Context, client *proxmox.Client, data OptionsResourceModel) error {
+	remote, err := client.GetClusterFirewallOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	logRatelimit := LogRatelimitModel{}
+	logRatelimit.LoadFromObject(ctx, data.LogRatelimit)
+
+	logRatelimitValue := logRatelimit.ToPVE()
+
+	apiReq := proxmox.UpdateClusterFirewallOptionsRequest{
+		Digest:       remote.Digest,
+		Enable:       data.Enable.ValueBoolPointer(),
+		Ebtables:     data.Ebtables.ValueBoolPointer(),
+		PolicyIn:     data.PolicyIn.ValueStringPointer(),
+		PolicyOut:    data.PolicyOut.ValueStringPointer(),
+		LogRatelimit: &logRatelimitValue,
+	}
+
+	// policy_forward is only supported by pve >= 8.2,
+	// so it's only sent when configured.
+	if !data.PolicyForward.IsUnknown() && !data.PolicyForward.IsNull() {
+		apiReq.PolicyForward = data.PolicyForward.ValueStringPointer()
+	}
+
+	tflog.Debug(ctx, "proxmox_cluster_firewall_options_update_request", map[string]any{"request": apiReq})
+
+	return client.UpdateClusterFirewallOptions(ctx, apiReq)
+}
+
+// read loads the remote options into data, using the proxmox
+// defaults for the options that are not set.
+func (r *OptionsResource) read(ctx context.Context, client *proxmox.Client, data *OptionsResourceModel) error {
+	remote, err := client.GetClusterFirewallOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	data.Enable = types.BoolValue(DFLT_OPTIONS_ENABLE)
+	if remote.Enable != nil {
+		data.Enable = types.BoolValue(*remote.Enable == 1)
+	}
+
+	data.Ebtables = types.BoolValue(DFLT_OPTIONS_EBTABLES)
+	if remote.Ebtables != nil {
+		data.Ebtables = types.BoolValue(*remote.Ebtables == 1)
+	}
+
+	data.PolicyIn = types.StringValue(DFLT_OPTIONS_POLICY_IN)
+	if remote.PolicyIn != nil {
+		data.PolicyIn = types.StringValue(*remote.PolicyIn)
+	}
+
+	data.PolicyOut = types.StringValue(DFLT_OPTIONS_POLICY_OUT)
+	if remote.PolicyOut != nil {
+		data.PolicyOut = types.StringValue(*remote.PolicyOut)
+	}
+
+	data.PolicyForward = types.StringValue(DFLT_OPTIONS_POLICY_FWD)
+	if remote.PolicyForward != nil {
+		data.PolicyForward = types.StringValue(*remote.PolicyForward)
+	}
+
+	logRatelimit := LogRatelimitModel{}
+	value := ""
+	if remote.LogRatelimit != nil {
+		value = *remote.LogRatelimit
+	}
+	if err := logRatelimit.LoadFromPVE(value); err != nil {
+		return err
+	}
+	data.LogRatelimit = logRatelimit.ToObject()
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package clusterattr implements the cluster attribute shared by the
+// resources and data sources, which selects the client of one of
+// the provider clusters.
+package clusterattr
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Name is the name of the cluster attribute.
+const Name = "cluster"
+
+const description = "The provider clusters key of the cluster to manage, " +
+	"the provider cluster is used when not set."
+
+// ResourceAttribute returns the cluster attribute of a resource,
+// the resource is replaced when it changes.
+func ResourceAttribute() rschema.StringAttribute {
+	return rschema.StringAttribute{
+		Optional:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// DataSourceAttribute returns the cluster attribute of a data source.
+func DataSourceAttribute() dschema.StringAttribute {
+	return dschema.StringAttribute{
+		Optional:    true,
+		Description: description,
+	}
+}
+
+// Client returns the client of the cluster attribute value, c is
+// the client of the provider cluster. An error diagnostic is added
+// when the cluster is unknown or its client cannot be created.
+func Client(ctx context.Context, c *proxmox.Client, cluster types.String, diags *diag.Diagnostics) *proxmox.Client {
+	if c == nil || cluster.IsNull() || cluster.IsUnknown() {
+		return c
+	}
+
+	client, err := c.SelectCluster(ctx, cluster.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(Name),
+			"Unable to Select Proxmox Cluster",
+			"The provider cannot create the client of the cluster.\n\n"+
+				"Proxmox Client Error: "+err.Error(),
+		)
+		return nil
+	}
+	return client
+}
+
+// PlanClient returns the client of the cluster configured for the
+// planned resource. It returns false when the cluster is not known
+// yet or cannot be selected, the plan time checks are then left to
+// the apply.
+func PlanClient(ctx context.Context, c *proxmox.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) (*proxmox.Client, bool) {
+	var cluster types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(Name), &cluster)...)
+	if resp.Diagnostics.HasError() || cluster.IsUnknown() {
+		return nil, false
+	}
+
+	c = Client(ctx, c, cluster, &resp.Diagnostics)
+	return c, !resp.Diagnostics.HasError()
+}
+
+// ImportID splits an import identifier formatted as cluster:id, the
+// cluster is null when the identifier has no cluster prefix (i.e.
+// 100, node/iface or vnet/2001:db8::/64), which imports from the
+// provider cluster.
+func ImportID(id string) (types.String, string) {
+	cluster, rest, found := strings.Cut(id, ":")
+	if !found || cluster == "" || strings.Contains(cluster, "/") || rest == "" {
+		return types.StringNull(), id
+	}
+	return types.StringValue(cluster), rest
+}
@@ -4,25 +4,198 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"terraform-provider-proxmox/internal/proxmox"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
-func getVMID(c *pve.PVE, data types.Int64) (int, error) {
+const (
+	// lxcPollInterval is the interval between the checks of the
+	// lxc status and commands.
+	lxcPollInterval = time.Second * 2
+	// lxcStatusTimeout bounds the waits for an lxc to start, stop
+	// or be deleted.
+	lxcStatusTimeout = time.Minute * 2
+)
 
-	if data.IsNull() || data.IsUnknown() {
-		vmid, err := c.Cluster.GetRandomVMID()
-		if err != nil {
-			return 0, err
+// createWithVMID calls create with the configured vmid. When no
+// vmid has been set, one is allocated within the provider vmid range.
+func createWithVMID(
+	ctx context.Context,
+	c *proxmox.Client,
+	data types.Int64,
+	create func(vmid int) error,
+) (int, error) {
+	if data.IsNull() || data.IsUnknown() || data.ValueInt64() == 0 {
+		return c.AllocateVMID(ctx, create)
+	}
+
+	vmid := int(data.ValueInt64())
+	return vmid, create(vmid)
+}
+
+// providerDefaults returns the provider defaults of c, which is
+// nil until the provider is configured.
+func providerDefaults(c *proxmox.Client) proxmox.Defaults {
+	if c == nil {
+		return proxmox.Defaults{}
+	}
+	return c.Defaults
+}
+
+// planDefault sets the planned value of the string attribute at p
+// to value when it is not configured, so the resolved value shows
+// in the plan. As the attribute plan modifiers only require the
+// replacement when it is configured, it is required here when the
+// resolved value differs from the state and replace is set.
+func planDefault(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+	p path.Path,
+	value string,
+	replace bool,
+) {
+	var config types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &config)...)
+	if resp.Diagnostics.HasError() || !config.IsNull() {
+		return
+	}
+
+	planned := types.StringNull()
+	if value != "" {
+		planned = types.StringValue(value)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, planned)...)
+
+	if !replace || req.State.Raw.IsNull() {
+		return
+	}
+	var state types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &state)...)
+	if !state.Equal(planned) {
+		resp.RequiresReplace = append(resp.RequiresReplace, p)
+	}
+}
+
+// planRequired reports a missing attribute at p when neither its
+// configuration nor the provider defaults set it.
+func planRequired(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+	p path.Path,
+) {
+	var planned types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &planned)...)
+	if !planned.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		p,
+		"Missing Attribute Value",
+		fmt.Sprintf("Attribute %s is required when the provider defaults block does not set it.", p),
+	)
+}
+
+// planLXCDefaults resolves the provider defaults of the attributes
+// shared by the lxc and lxc template resources.
+func planLXCDefaults(ctx context.Context, c *proxmox.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defaults := providerDefaults(c)
+
+	planDefault(ctx, req, resp, path.Root("node"), defaults.Node, true)
+	planRequired(ctx, req, resp, path.Root("node"))
+	planDefault(ctx, req, resp, path.Root("nameserver"), defaults.Nameserver, true)
+
+	var networks types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("networks"), &networks)...)
+	if !networks.IsNull() && !networks.IsUnknown() {
+		for i := range networks.Elements() {
+			p := path.Root("networks").AtListIndex(i).AtName("bridge")
+			planDefault(ctx, req, resp, p, defaults.Bridge, false)
 		}
-		return vmid, nil
 	}
+}
+
+// lockLXC runs fn holding the locks of the given vmids (i.e. the
+// lxc and its clone source) and a slot of node. fn retries its
+// calls itself, either through the client methods or c.Retry.
+func lockLXC(
+	ctx context.Context,
+	c *proxmox.Client,
+	node string,
+	vmids []int,
+	fn func() error,
+) error {
+	keys := []string{}
+	for _, vmid := range vmids {
+		keys = append(keys, proxmox.GuestKey(vmid))
+	}
+	return c.Locks.Do(ctx, node, keys, fn)
+}
+
+// pollLXC calls done every lxcPollInterval until it reports true,
+// fails or timeout (when not 0) or ctx expire. done retries its
+// calls itself.
+func pollLXC(ctx context.Context, timeout time.Duration, done func() (bool, error)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		ok, err := done()
+		if err != nil || ok {
+			return err
+		}
 
-	return int(data.ValueInt64()), nil
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lxcPollInterval):
+		}
+	}
+}
+
+// getLXCStatus retrieves the status of the lxc, i.e. running.
+func getLXCStatus(ctx context.Context, c *proxmox.Client, node string, vmid int) (string, error) {
+	var remoteStatus *pve.LXCStatusResponse
+	err := c.Retry(ctx, func() (err error) {
+		remoteStatus, err = c.LXC.GetStatus(node, vmid)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return remoteStatus.Status, nil
+}
+
+// plannedVMACLPath returns the acl path of the guest whose id is
+// planned at p, /vms when the id is allocated during the apply.
+func plannedVMACLPath(ctx context.Context, resp *resource.ModifyPlanResponse, p path.Path) string {
+	var id types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, p, &id)...)
+	if id.IsNull() || id.IsUnknown() {
+		return "/vms"
+	}
+	return fmt.Sprintf("/vms/%d", id.ValueInt64())
+}
+
+// redactLXCRequest returns req without its sensitive values, so it
+// can be logged.
+func redactLXCRequest(req pve.CreateLxcRequest) pve.CreateLxcRequest {
+	if req.Password != "" {
+		req.Password = "***"
+	}
+	return req
 }
 
 func formatSSHPublicKey(keys []types.String) string {
@@ -63,64 +236,59 @@ func newPVELXCTplNets(ctx context.Context, objs []types.Object) []pve.LxcNet {
 	return nets
 }
 
+// updateLXCStatus starts or stops the lxc, unless its status is
+// already desiredStatus, and waits for the status change.
 func updateLXCStatus(
 	ctx context.Context,
-	c *pve.PVE,
+	c *proxmox.Client,
 	node string,
 	vmid int,
 	desiredStatus string,
 ) error {
-	retries := 5
-	try := 0
-	for true {
-		try++
-		time.Sleep(time.Second * 8)
-
-		remoteStatus, err := c.LXC.GetStatus(
-			node,
-			vmid,
-		)
-		if err != nil {
-			return err
-		}
-
-		if desiredStatus == remoteStatus.Status {
-			break
-		}
-
-		tflog.Debug(ctx, "debug_lxc_status", map[string]any{"vmid": vmid, "current_status": remoteStatus.Status, "desired_status": desiredStatus})
-		switch desiredStatus {
-		case string(pve.LXC_STATUS_RUNNING):
-			if remoteStatus.Status != string(pve.LXC_STATUS_STOPPED) {
-				continue
-			}
-			_, err = c.LXC.Start(pve.LXCStartRequest{Node: node, ID: vmid})
-			break
-
-		case string(pve.LXC_STATUS_STOPPED):
-			if remoteStatus.Status != string(pve.LXC_STATUS_RUNNING) {
-				continue
-			}
-			_, err = c.LXC.Stop(pve.LXCStopRequest{Node: node, ID: vmid})
-			break
-		default:
-			err = fmt.Errorf("unexpected status value, got %s", desiredStatus)
-			break
-		}
+	status, err := getLXCStatus(ctx, c, node, vmid)
+	if err != nil {
+		return err
+	}
+	if status == desiredStatus {
+		return nil
+	}
 
-		if try <= retries {
-			continue
-		} else {
-			return err
-		}
+	tflog.Debug(ctx, "debug_lxc_status", map[string]any{"vmid": vmid, "current_status": status, "desired_status": desiredStatus})
+	switch desiredStatus {
+	case string(pve.LXC_STATUS_RUNNING):
+		err = lockLXC(ctx, c, node, []int{vmid}, func() error {
+			return c.RetryLocked(ctx, func() error {
+				_, err := c.LXC.Start(pve.LXCStartRequest{Node: node, ID: vmid})
+				return err
+			})
+		})
+	case string(pve.LXC_STATUS_STOPPED):
+		err = lockLXC(ctx, c, node, []int{vmid}, func() error {
+			return c.RetryLocked(ctx, func() error {
+				_, err := c.LXC.Stop(pve.LXCStopRequest{Node: node, ID: vmid})
+				return err
+			})
+		})
+	default:
+		return fmt.Errorf("unexpected status value, got %s", desiredStatus)
+	}
+	if err != nil {
+		return err
 	}
 
+	err = pollLXC(ctx, lxcStatusTimeout, func() (bool, error) {
+		status, err := getLXCStatus(ctx, c, node, vmid)
+		return status == desiredStatus, err
+	})
+	if err != nil {
+		return fmt.Errorf("lxc %d did not become %s: %w", vmid, desiredStatus, err)
+	}
 	return nil
 }
 
 func computeLXCNetIPs(
 	ctx context.Context,
-	c *pve.PVE,
+	c *proxmox.Client,
 	node string,
 	vmid int,
 	nets []types.Object,
@@ -134,7 +302,11 @@ func computeLXCNetIPs(
 		// and store them in the map below for easy
 		// access through the iface name.
 		ifacesMap := map[string]pve.GetLxcInterfaceResponse{}
-		ifaces, err := c.LXC.GetInterfaces(node, vmid)
+		var ifaces []pve.GetLxcInterfaceResponse
+		err := c.Retry(ctx, func() (err error) {
+			ifaces, err = c.LXC.GetInterfaces(node, vmid)
+			return err
+		})
 		if err != nil {
 			continue
 		}
@@ -188,61 +360,56 @@ func newLXCNetsResourceModel(ctx context.Context, obj []types.Object) []LXCNetRe
 
 func runLXCCommands(
 	ctx context.Context,
-	c *pve.PVE,
+	c *proxmox.Client,
 	vmid int,
 	cmds []types.String,
 ) error {
-	for _, cmd := range cmds {
+	// cmds are sensitive, only their index is logged.
+	for i, cmd := range cmds {
 		var execId string
-		var execErr error
 		cmdstr := cmd.ValueString()
 
-		for retry := 0; retry < 3; retry++ {
-			tflog.Info(ctx, "executing cmd", map[string]any{"cmd": cmdstr})
-			execId, execErr = c.LXC.ExecAsync(vmid, "bash", cmdstr)
-			if execErr != nil {
-				time.Sleep(time.Second * 3)
-				continue
-			}
-			break
-		}
-		if execErr != nil {
-			return execErr
+		tflog.Info(ctx, "executing cmd", map[string]any{"cmd": i})
+		err := c.Retry(ctx, func() (err error) {
+			execId, err = c.LXC.ExecAsync(vmid, "bash", cmdstr)
+			return err
+		})
+		if err != nil {
+			return err
 		}
 
-		for retry := 0; retry < 3; retry++ {
-			time.Sleep(time.Second * 2)
-			result, err := c.LXC.GetCMDResult(execId)
-			execErr = err
-			if execErr != nil {
-				continue
+		var result *pve.CMDResult
+		err = pollLXC(ctx, 0, func() (bool, error) {
+			err := c.Retry(ctx, func() (err error) {
+				result, err = c.LXC.GetCMDResult(execId)
+				return err
+			})
+			if err != nil {
+				return false, err
 			}
-
-			switch result.Status {
-			case "FAILED":
-				if result.Error != nil {
-					execErr = errors.New(*result.Error)
-				}
-				continue
-			case "RUNNING":
-				tflog.Info(ctx, "cmd still running", map[string]any{"try": retry + 1, "cmd": cmdstr})
-
-				// just to stop incrementing
-				retry = retry - 1
-				continue
-			case "SUCCEEDED":
-				if *result.ExitCode != 0 {
-					execErr = errors.New(*result.Output)
-				} else {
-					tflog.Info(ctx, "cmd succeeded", map[string]any{"try": retry + 1, "cmd": cmdstr})
-				}
-				break
+			if result.Status == "RUNNING" {
+				tflog.Info(ctx, "cmd still running", map[string]any{"cmd": i})
+				return false, nil
 			}
-
+			return true, nil
+		})
+		if err != nil {
+			return err
 		}
-		if execErr != nil {
-			return execErr
+
+		switch {
+		case result.Status == "FAILED":
+			if result.Error != nil {
+				return errors.New(*result.Error)
+			}
+			return fmt.Errorf("cmd %d failed", i)
+		case result.ExitCode != nil && *result.ExitCode != 0:
+			if result.Output != nil {
+				return errors.New(*result.Output)
+			}
+			return fmt.Errorf("cmd %d exited with code %d", i, *result.ExitCode)
 		}
+		tflog.Info(ctx, "cmd succeeded", map[string]any{"cmd": i})
 	}
 
 	return nil
@@ -250,11 +417,10 @@ func runLXCCommands(
 
 func deleteLXC(
 	ctx context.Context,
-	c *pve.PVE,
+	c *proxmox.Client,
 	node string,
 	vmid int,
 ) error {
-	time.Sleep(time.Second * 15)
 	// Stop the lxc if running
 	if err := updateLXCStatus(
 		ctx,
@@ -266,40 +432,34 @@ func deleteLXC(
 		return err
 	}
 
-	// check if stopped
-	for true {
-		time.Sleep(time.Second * 5)
-		remoteStatus, err := c.LXC.GetStatus(node, vmid)
-		if err != nil {
+	if err := lockLXC(ctx, c, node, []int{vmid}, func() error {
+		var upid string
+		if err := c.RetryLocked(ctx, func() (err error) {
+			upid, err = c.LXC.Delete(node, vmid, nil)
+			return err
+		}); err != nil {
 			return err
 		}
-		if remoteStatus.Status != string(pve.LXC_STATUS_STOPPED) {
-			continue
-		}
-		break
-	}
-
-	if _, err := c.LXC.Delete(node, vmid, nil); err != nil {
+		// the lxc is locked until its destroy task stops.
+		return c.WaitTask(ctx, upid)
+	}); err != nil {
 		return err
 	}
 
 	// check if deleted
-	for true {
-		time.Sleep(time.Second * 2)
-		idAvailable, err := c.Cluster.IsVMIDAvailable(vmid)
-		if err != nil {
+	return pollLXC(ctx, lxcStatusTimeout, func() (bool, error) {
+		var idAvailable bool
+		err := c.Retry(ctx, func() (err error) {
+			idAvailable, err = c.Cluster.IsVMIDAvailable(vmid)
 			return err
-		}
-		if !idAvailable {
-			continue
-		}
-		break
-	}
-	return nil
+		})
+		return idAvailable, err
+	})
 }
 
 func computeLXCCloneNetIPs(
-	c *pve.PVE,
+	ctx context.Context,
+	c *proxmox.Client,
 	node string,
 	vmid int,
 ) (types.List, error) {
@@ -311,7 +471,11 @@ func computeLXCCloneNetIPs(
 		// retrieve lxc interfaces of running lxc
 		// and store them in the map below for easy
 		// access through the iface name.
-		ifaces, err := c.LXC.GetInterfaces(node, vmid)
+		var ifaces []pve.GetLxcInterfaceResponse
+		err := c.Retry(ctx, func() (err error) {
+			ifaces, err = c.LXC.GetInterfaces(node, vmid)
+			return err
+		})
 		if err != nil {
 			continue
 		}
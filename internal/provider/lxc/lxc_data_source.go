@@ -0,0 +1,507 @@
+package lxc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/iolave/go-proxmox/pkg/pve"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &lxcDataSource{}
+	_ datasource.DataSourceWithConfigure = &lxcDataSource{}
+)
+
+// NewLXCDataSource is a helper function to simplify the provider implementation.
+func NewLXCDataSource() datasource.DataSource {
+	return &lxcDataSource{}
+}
+
+// lxcDataSource is the data source implementation.
+type lxcDataSource struct {
+	client *proxmox.Client
+}
+
+// lxcDataSourceModel describes the data source data model, it
+// mirrors the LXCResourceModel config attributes.
+type lxcDataSourceModel struct {
+	Cluster      types.String              `tfsdk:"cluster"`
+	Node         types.String              `tfsdk:"node"`
+	VMID         types.Int64               `tfsdk:"id"`
+	Hostname     types.String              `tfsdk:"hostname"`
+	Status       types.String              `tfsdk:"status"`
+	Arch         types.String              `tfsdk:"arch"`
+	OSType       types.String              `tfsdk:"os_type"`
+	Cores        types.Int64               `tfsdk:"cores"`
+	Memory       types.Int64               `tfsdk:"memory"`
+	Swap         types.Int64               `tfsdk:"swap"`
+	Nameserver   types.String              `tfsdk:"nameserver"`
+	SearchDomain types.String              `tfsdk:"search_domain"`
+	OnBoot       types.Bool                `tfsdk:"on_boot"`
+	Unprivileged types.Bool                `tfsdk:"unprivileged"`
+	Template     types.Bool                `tfsdk:"template"`
+	Protection   types.Bool                `tfsdk:"protection"`
+	Hookscript   types.String              `tfsdk:"hookscript"`
+	Description  types.String              `tfsdk:"description"`
+	Tags         types.List                `tfsdk:"tags"`
+	Features     *LXCFeaturesResourceModel `tfsdk:"features"`
+	RootFS       *LXCRootFSResourceModel   `tfsdk:"root_fs"`
+	Networks     []LXCNetResourceModel     `tfsdk:"networks"`
+}
+
+// Metadata returns the data source type name.
+func (d *lxcDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "lxc"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *lxcDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	netSchema := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name":        schema.StringAttribute{Computed: true},
+			"bridge":      schema.StringAttribute{Computed: true, Description: DESC_LXC_NET_BRIDGE},
+			"firewall":    schema.BoolAttribute{Computed: true},
+			"gateway":     schema.StringAttribute{Computed: true},
+			"gateway6":    schema.StringAttribute{Computed: true},
+			"hw_address":  schema.StringAttribute{Computed: true},
+			"ip":          schema.StringAttribute{Computed: true},
+			"ip6":         schema.StringAttribute{Computed: true},
+			"link_down":   schema.BoolAttribute{Computed: true},
+			"mtu":         schema.Int64Attribute{Computed: true},
+			"rate":        schema.Int64Attribute{Computed: true},
+			"tag":         schema.Int64Attribute{Computed: true},
+			"computed_ip": schema.StringAttribute{Computed: true, Description: DESC_DS_LXC_NET_IP},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: DESC_DS_LXC,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"node": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: DESC_DS_LXC_NODE,
+			},
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: DESC_DS_LXC_ID,
+			},
+			"hostname": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: DESC_DS_LXC_HOSTNAME,
+			},
+			"status":        schema.StringAttribute{Computed: true, Description: DESC_DS_LXC_STATUS},
+			"arch":          schema.StringAttribute{Computed: true, Description: DESC_LXC_ARCH},
+			"os_type":       schema.StringAttribute{Computed: true, Description: DESC_LXC_OSTYPE},
+			"cores":         schema.Int64Attribute{Computed: true, Description: DESC_LXC_CORES},
+			"memory":        schema.Int64Attribute{Computed: true, Description: DESC_LXC_MEM},
+			"swap":          schema.Int64Attribute{Computed: true, Description: DESC_LXC_SWAP},
+			"nameserver":    schema.StringAttribute{Computed: true, Description: DESC_LXC_NS},
+			"search_domain": schema.StringAttribute{Computed: true, Description: DESC_LXC_SDOMAIN},
+			"on_boot":       schema.BoolAttribute{Computed: true, Description: DESC_LXC_ONBOOT},
+			"unprivileged":  schema.BoolAttribute{Computed: true, Description: DESC_LXC_UNPRIV},
+			"template":      schema.BoolAttribute{Computed: true, Description: DESC_LXC_TEMPLATE},
+			"protection":    schema.BoolAttribute{Computed: true, Description: DESC_LXC_PROTECTON},
+			"hookscript":    schema.StringAttribute{Computed: true, Description: DESC_LXC_HOOK},
+			"description":   schema.StringAttribute{Computed: true, Description: DESC_LXC_DESC},
+			"tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: DESC_LXC_TAGS,
+			},
+			"features": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: DESC_LXC_FEATS,
+				Attributes: map[string]schema.Attribute{
+					"force_rw_sys": schema.BoolAttribute{Computed: true},
+					"fuse":         schema.BoolAttribute{Computed: true},
+					"key_ctl":      schema.BoolAttribute{Computed: true},
+					"nesting":      schema.BoolAttribute{Computed: true},
+				},
+			},
+			"root_fs": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: DESC_LXC_ROOTFS,
+				Attributes: map[string]schema.Attribute{
+					"volume":    schema.StringAttribute{Computed: true},
+					"acl":       schema.BoolAttribute{Computed: true},
+					"quota":     schema.BoolAttribute{Computed: true},
+					"replicate": schema.BoolAttribute{Computed: true},
+					"read_only": schema.BoolAttribute{Computed: true},
+					"shared":    schema.BoolAttribute{Computed: true},
+					"disk_size": schema.Int64Attribute{Computed: true, Description: DESC_DS_LXC_DISK_SIZE},
+				},
+			},
+			"networks": schema.ListNestedAttribute{
+				NestedObject: netSchema,
+				Computed:     true,
+				Description:  DESC_LXC_NET,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *lxcDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state lxcDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.VMID.IsNull() && state.Hostname.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing LXC Lookup Attribute",
+			"Either the id or the hostname attribute must be set.",
+		)
+		return
+	}
+
+	node, vmid, err := d.lookup(ctx, client, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox LXC",
+			err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "reading lxc", map[string]any{"node": node, "vmid": vmid})
+
+	config, err := client.GetLXCConfig(ctx, node, vmid)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Proxmox LXC", err.Error())
+		return
+	}
+	status, err := client.GetLXCStatus(ctx, node, vmid)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Proxmox LXC", err.Error())
+		return
+	}
+
+	// interface ips are only reported by running containers.
+	ips := map[string]string{}
+	if status.Status == string(pve.LXC_STATUS_RUNNING) {
+		ifaces, err := client.GetLXCInterfaces(ctx, node, vmid)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Proxmox LXC Interfaces", err.Error())
+			return
+		}
+		for _, iface := range ifaces {
+			ips[iface.Name] = iface.Inet
+		}
+	}
+
+	state.Node = types.StringValue(node)
+	state.VMID = types.Int64Value(int64(vmid))
+	state.Status = types.StringValue(status.Status)
+	if err := state.loadConfig(config, ips); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse proxmox lxc config",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *lxcDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// lookup finds the node and vmid of the container matching the
+// configured id, hostname and node.
+func (d *lxcDataSource) lookup(ctx context.Context, client *proxmox.Client, state lxcDataSourceModel) (string, int, error) {
+	resources, err := client.GetClusterResources(ctx, "vm")
+	if err != nil {
+		return "", 0, err
+	}
+
+	matches := []proxmox.ClusterResource{}
+	for _, r := range resources {
+		switch {
+		case r.Type != "lxc":
+			continue
+		case !state.VMID.IsNull() && int64(r.VMID) != state.VMID.ValueInt64():
+			continue
+		case !state.Hostname.IsNull() && r.Name != state.Hostname.ValueString():
+			continue
+		case !state.Node.IsNull() && r.Node != state.Node.ValueString():
+			continue
+		}
+		matches = append(matches, r)
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", 0, fmt.Errorf("no lxc found matching %s", state.lookupDescription())
+	case 1:
+		return matches[0].Node, int(matches[0].VMID), nil
+	}
+
+	ids := []string{}
+	for _, m := range matches {
+		ids = append(ids, strconv.Itoa(int(m.VMID)))
+	}
+	return "", 0, fmt.Errorf("%d lxcs found matching %s (%s), set the id or the node to narrow the lookup",
+		len(matches), state.lookupDescription(), strings.Join(ids, ", "))
+}
+
+func (m lxcDataSourceModel) lookupDescription() string {
+	items := []string{}
+	if !m.VMID.IsNull() {
+		items = append(items, fmt.Sprintf("id %d", m.VMID.ValueInt64()))
+	}
+	if !m.Hostname.IsNull() {
+		items = append(items, fmt.Sprintf("hostname %q", m.Hostname.ValueString()))
+	}
+	if !m.Node.IsNull() {
+		items = append(items, fmt.Sprintf("node %q", m.Node.ValueString()))
+	}
+	return strings.Join(items, ", ")
+}
+
+// loadConfig loads the proxmox lxc config into the model, ips maps
+// the interface names to the addresses reported by the container.
+func (m *lxcDataSourceModel) loadConfig(config proxmox.LXCConfig, ips map[string]string) error {
+	var err error
+
+	m.Hostname = optional.String(config["hostname"])
+	m.Arch = optional.String(config["arch"])
+	m.OSType = optional.String(config["ostype"])
+	m.Nameserver = optional.String(config["nameserver"])
+	m.SearchDomain = optional.String(config["searchdomain"])
+	m.Hookscript = optional.String(config["hookscript"])
+	m.Description = optional.String(config["description"])
+	m.OnBoot = types.BoolValue(config["onboot"] == "1")
+	m.Unprivileged = types.BoolValue(config["unprivileged"] == "1")
+	m.Template = types.BoolValue(config["template"] == "1")
+	m.Protection = types.BoolValue(config["protection"] == "1")
+
+	if m.Cores, err = optionalLXCInt64(config, "cores"); err != nil {
+		return err
+	}
+	if m.Memory, err = optionalLXCInt64(config, "memory"); err != nil {
+		return err
+	}
+	if m.Swap, err = optionalLXCInt64(config, "swap"); err != nil {
+		return err
+	}
+
+	tags := []attr.Value{}
+	for _, tag := range strings.FieldsFunc(config["tags"], func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	}) {
+		tags = append(tags, types.StringValue(tag))
+	}
+	m.Tags = types.ListValueMust(types.StringType, tags)
+
+	m.Features = nil
+	if value, ok := config["features"]; ok {
+		props := parseLXCProperties(value, "")
+		m.Features = &LXCFeaturesResourceModel{
+			ForceRWSys: types.BoolValue(props["force_rw_sys"] == "1"),
+			Fuse:       types.BoolValue(props["fuse"] == "1"),
+			KeyCTL:     types.BoolValue(props["keyctl"] == "1"),
+			Nesting:    types.BoolValue(props["nesting"] == "1"),
+		}
+	}
+
+	m.RootFS = nil
+	if value, ok := config["rootfs"]; ok {
+		props := parseLXCProperties(value, "volume")
+		size, err := parseLXCDiskSize(props["size"])
+		if err != nil {
+			return err
+		}
+		m.RootFS = &LXCRootFSResourceModel{
+			Volume:    types.StringValue(props["volume"]),
+			ACL:       types.BoolValue(props["acl"] == "1"),
+			Quota:     types.BoolValue(props["quota"] == "1"),
+			Replicate: types.BoolValue(props["replicate"] != "0"),
+			ReadOnly:  types.BoolValue(props["ro"] == "1"),
+			Shared:    types.BoolValue(props["shared"] == "1"),
+			DiskSize:  size,
+		}
+	}
+
+	// net0, net1... are sorted by their index.
+	keys := []int{}
+	for k := range config {
+		if index, ok := strings.CutPrefix(k, "net"); ok {
+			if n, err := strconv.Atoi(index); err == nil {
+				keys = append(keys, n)
+			}
+		}
+	}
+	sort.Ints(keys)
+
+	m.Networks = []LXCNetResourceModel{}
+	for _, k := range keys {
+		net, err := parseLXCNet(config[fmt.Sprintf("net%d", k)])
+		if err != nil {
+			return err
+		}
+		if ip, ok := ips[net.Name]; ok && ip != "" {
+			net.ComputedIP = &ip
+		}
+		m.Networks = append(m.Networks, net)
+	}
+
+	return nil
+}
+
+// parseLXCNet parses a proxmox lxc net property string, i.e.
+// "name=eth0,bridge=vmbr0,hwaddr=BC:24:11:00:00:01,ip=dhcp".
+func parseLXCNet(value string) (LXCNetResourceModel, error) {
+	props := parseLXCProperties(value, "")
+	net := LXCNetResourceModel{Name: props["name"]}
+
+	optional := func(key string) *string {
+		if v, ok := props[key]; ok {
+			return &v
+		}
+		return nil
+	}
+	flag := func(key string) *bool {
+		if v, ok := props[key]; ok {
+			b := v == "1"
+			return &b
+		}
+		return nil
+	}
+	number := func(key string) (*int, error) {
+		v, ok := props[key]
+		if !ok {
+			return nil, nil
+		}
+		// rate is a float (MB/s), it is truncated.
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lxc net %s value %q", key, v)
+		}
+		n := int(f)
+		return &n, nil
+	}
+
+	var err error
+	net.Bridge = optional("bridge")
+	net.Firewall = flag("firewall")
+	net.GW = optional("gw")
+	net.GW6 = optional("gw6")
+	net.HWAddr = optional("hwaddr")
+	net.IP = optional("ip")
+	net.IP6 = optional("ip6")
+	net.LinkDown = flag("link_down")
+	if net.MTU, err = number("mtu"); err != nil {
+		return net, err
+	}
+	if net.Rate, err = number("rate"); err != nil {
+		return net, err
+	}
+	if net.Tag, err = number("tag"); err != nil {
+		return net, err
+	}
+
+	return net, nil
+}
+
+// parseLXCProperties parses a proxmox property string. The value
+// of the first item is stored under defaultKey when it has no key,
+// i.e. "local-lvm:vm-100-disk-0,size=8G".
+func parseLXCProperties(value, defaultKey string) map[string]string {
+	props := map[string]string{}
+	for i, item := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok && i == 0 && defaultKey != "" {
+			props[defaultKey] = item
+			continue
+		}
+		props[k] = v
+	}
+	return props
+}
+
+// parseLXCDiskSize converts a proxmox disk size (i.e. "8G" or
+// "512M") to GiB, rounded up.
+func parseLXCDiskSize(value string) (types.Int64, error) {
+	if value == "" {
+		return types.Int64Null(), nil
+	}
+
+	units := map[byte]float64{
+		'K': 1.0 / (1024 * 1024),
+		'M': 1.0 / 1024,
+		'G': 1,
+		'T': 1024,
+	}
+
+	unit, ok := units[value[len(value)-1]]
+	number := value[:len(value)-1]
+	if !ok {
+		// sizes without unit are in bytes.
+		unit = 1.0 / (1024 * 1024 * 1024)
+		number = value
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return types.Int64Null(), fmt.Errorf("invalid lxc disk size %q", value)
+	}
+
+	return types.Int64Value(int64(math.Ceil(n * unit))), nil
+}
+
+func optionalLXCInt64(config proxmox.LXCConfig, key string) (types.Int64, error) {
+	value, ok := config[key]
+	if !ok {
+		return types.Int64Null(), nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return types.Int64Null(), fmt.Errorf("invalid lxc %s value %q", key, value)
+	}
+	return types.Int64Value(n), nil
+}
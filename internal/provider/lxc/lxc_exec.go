@@ -3,13 +3,14 @@ package lxc
 import (
 	"context"
 	"fmt"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -22,13 +23,14 @@ func NewLXCExecResource() resource.Resource {
 
 // LXCExecResource defines the resource implementation.
 type LXCExecResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // LXCExecResourceModel describes the resource data model.
 type LXCExecResourceModel struct {
-	VMID types.Int64    `tfsdk:"id"`
-	CMDs []types.String `tfsdk:"cmds"`
+	Cluster types.String   `tfsdk:"cluster"`
+	VMID    types.Int64    `tfsdk:"id"`
+	CMDs    []types.String `tfsdk:"cmds"`
 }
 
 func (r *LXCExecResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,6 +43,7 @@ func (r *LXCExecResource) Schema(ctx context.Context, req resource.SchemaRequest
 		MarkdownDescription: MD_RSRC_LXC_EXEC,
 		Description:         DESC_RSRC_LXC_EXEC,
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
 			"id": schema.Int64Attribute{
 				Description: DESC_LXC_ID,
 				Required:    true,
@@ -65,11 +68,11 @@ func (r *LXCExecResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -87,9 +90,14 @@ func (r *LXCExecResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	vmid := int(data.VMID.ValueInt64())
 
-	if err := runLXCCommands(ctx, r.client, vmid, data.CMDs); err != nil {
+	if err := runLXCCommands(ctx, client, vmid, data.CMDs); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to run commands inside lxc , got error: %s", err))
 		return
 	}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -21,6 +25,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LXCLinkedCloneResource{}
 var _ resource.ResourceWithImportState = &LXCLinkedCloneResource{}
+var _ resource.ResourceWithModifyPlan = &LXCLinkedCloneResource{}
 
 func NewLXCLinkedCloneResource() resource.Resource {
 	return &LXCLinkedCloneResource{}
@@ -28,11 +33,12 @@ func NewLXCLinkedCloneResource() resource.Resource {
 
 // LXCLinkedCloneResource defines the resource implementation.
 type LXCLinkedCloneResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // LXCLinkedCloneResourceModel describes the resource data model.
 type LXCLinkedCloneResourceModel struct {
+	Cluster  types.String `tfsdk:"cluster"`
 	VMID     types.Int64  `tfsdk:"source_id"`
 	Node     types.String `tfsdk:"node"`
 	NewVMID  types.Int64  `tfsdk:"id"`
@@ -58,13 +64,15 @@ func (r *LXCLinkedCloneResource) Schema(ctx context.Context, req resource.Schema
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Do a linked clone of an lxc",
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
 			"source_id": schema.Int64Attribute{
 				Description: DESC_LXC_ID,
 				Required:    true,
 			},
 			"node": schema.StringAttribute{
-				Description: DESC_LXC_NODE,
-				Required:    true,
+				Description: DESC_LXC_NODE + " " + DESC_LXC_DFLT_NODE,
+				Optional:    true,
+				Computed:    true,
 			},
 			"id": schema.Int64Attribute{
 				Description: DESC_LXC_ID,
@@ -99,11 +107,11 @@ func (r *LXCLinkedCloneResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"pool": schema.StringAttribute{
-				Description: DESC_LXC_POOL,
+				Description: DESC_LXC_POOL + " " + DESC_LXC_DFLT_POOL,
 				Optional:    true,
-				//Computed:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"snapshot_name": schema.StringAttribute{
@@ -167,11 +175,11 @@ func (r *LXCLinkedCloneResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -180,6 +188,34 @@ func (r *LXCLinkedCloneResource) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
+// ModifyPlan resolves the provider defaults of the attributes that
+// are not configured and reports the privileges missing to clone
+// the container.
+func (r *LXCLinkedCloneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	defaults := providerDefaults(client)
+	planDefault(ctx, req, resp, path.Root("node"), defaults.Node, false)
+	planRequired(ctx, req, resp, path.Root("node"))
+	planDefault(ctx, req, resp, path.Root("pool"), defaults.Pool, true)
+
+	if preflight.Creates(req) {
+		preflight.Require(client, &resp.Diagnostics, "VM.Clone", plannedVMACLPath(ctx, resp, path.Root("source_id")))
+		allocatePaths := []string{plannedVMACLPath(ctx, resp, path.Root("id"))}
+		if pool, ok := preflight.PlannedString(ctx, resp, path.Root("pool")); ok {
+			allocatePaths = append(allocatePaths, "/pool/"+pool)
+		}
+		preflight.Require(client, &resp.Diagnostics, "VM.Allocate", allocatePaths...)
+	}
+}
+
 func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data LXCLinkedCloneResourceModel
 
@@ -189,6 +225,11 @@ func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	sourceId := int(data.VMID.ValueInt64())
 	if sourceId == 0 {
 		resp.Diagnostics.AddError("Client Error", "source_id property is required")
@@ -201,20 +242,9 @@ func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	targetId := int(data.NewVMID.ValueInt64())
-	if targetId == 0 {
-		id, err := getVMID(r.client, data.NewVMID)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to generate a vmid, got error: %s", err.Error()))
-			return
-		}
-		targetId = id
-	}
-
 	apiReq := pve.CloneLxcRequest{
-		Node:    node,
-		VMID:    sourceId,
-		NewVMID: targetId,
+		Node: node,
+		VMID: sourceId,
 	}
 
 	if data.BWLimit.ValueInt64Pointer() != nil {
@@ -236,7 +266,26 @@ func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.Create
 		"request": apiReq,
 	})
 
-	if _, err := r.client.LXC.Clone(apiReq); err != nil {
+	// if no new vmid has been set, one is allocated within the
+	// provider vmid range.
+	targetId, err := createWithVMID(ctx, client, data.NewVMID, func(vmid int) error {
+		apiReq.NewVMID = vmid
+		// clones of the same source are serialized, as
+		// proxmox locks the source during the clone.
+		return lockLXC(ctx, client, node, []int{sourceId, vmid}, func() error {
+			var upid string
+			if err := client.RetryLocked(ctx, func() (err error) {
+				upid, err = client.LXC.Clone(apiReq)
+				return err
+			}); err != nil {
+				return err
+			}
+			// the source and the clone are locked until the clone
+			// task stops.
+			return client.WaitTask(ctx, upid)
+		})
+	})
+	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clone lxc, got error: %s", err.Error()))
 		return
 	}
@@ -252,11 +301,11 @@ func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.Create
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
 	status := data.Status.ValueString()
-	if err := updateLXCStatus(ctx, r.client, node, targetId, status); err != nil {
+	if err := updateLXCStatus(ctx, client, node, targetId, status); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lxc status, got error: %s", err.Error()))
 		if err := deleteLXC(
 			ctx,
-			r.client,
+			client,
 			node,
 			targetId,
 		); err != nil {
@@ -270,7 +319,8 @@ func (r *LXCLinkedCloneResource) Create(ctx context.Context, req resource.Create
 	// If the clone is running, we compute the networks
 	if status == string(pve.LXC_STATUS_RUNNING) {
 		computedNets, err := computeLXCCloneNetIPs(
-			r.client,
+			ctx,
+			client,
 			node,
 			targetId,
 		)
@@ -292,12 +342,21 @@ func (r *LXCLinkedCloneResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	id := int(data.NewVMID.ValueInt64())
 	node := data.Node.ValueString()
 
 	desiredStatus := data.Status.ValueString()
 
-	remoteData, err := r.client.LXC.GetByID(node, id)
+	var remoteData *pve.LXC
+	err := client.Retry(ctx, func() (err error) {
+		remoteData, err = client.LXC.GetByID(node, id)
+		return err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node lxc, got error: %s", err))
 		return
@@ -314,7 +373,8 @@ func (r *LXCLinkedCloneResource) Read(ctx context.Context, req resource.ReadRequ
 	// If the clone is running, we compute the networks
 	if desiredStatus == string(pve.LXC_STATUS_RUNNING) {
 		computedNets, err := computeLXCCloneNetIPs(
-			r.client,
+			ctx,
+			client,
 			node,
 			id,
 		)
@@ -352,7 +412,12 @@ func (r *LXCLinkedCloneResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	if err := updateLXCStatus(ctx, r.client, node, id, status); err != nil {
+	client := clusterattr.Client(ctx, r.client, plan.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := updateLXCStatus(ctx, client, node, id, status); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lxc status, got error: %s", err))
 		return
 	}
@@ -362,7 +427,8 @@ func (r *LXCLinkedCloneResource) Update(ctx context.Context, req resource.Update
 	// If the clone is running, we compute the networks
 	if status == string(pve.LXC_STATUS_RUNNING) {
 		computedNets, err := computeLXCCloneNetIPs(
-			r.client,
+			ctx,
+			client,
 			node,
 			id,
 		)
@@ -385,9 +451,14 @@ func (r *LXCLinkedCloneResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := deleteLXC(
 		ctx,
-		r.client,
+		client,
 		data.Node.ValueString(),
 		int(data.NewVMID.ValueInt64()),
 	); err != nil {
@@ -397,14 +468,16 @@ func (r *LXCLinkedCloneResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *LXCLinkedCloneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	cluster, importID := clusterattr.ImportID(req.ID)
+	id, err := strconv.Atoi(importID)
 	if err != nil {
 		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import lxc linked clone, got error: %s", err))
 		return
 	}
 
 	state := LXCLinkedCloneResourceModel{
-		VMID: basetypes.NewInt64Value(int64(id)),
+		Cluster: cluster,
+		VMID:    basetypes.NewInt64Value(int64(id)),
 	}
 
 	diags := resp.State.Set(ctx, &state)
@@ -0,0 +1,466 @@
+package lxc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LXCOSTplResource{}
+var _ resource.ResourceWithValidateConfig = &LXCOSTplResource{}
+var _ resource.ResourceWithModifyPlan = &LXCOSTplResource{}
+
+func NewLXCOSTplResource() resource.Resource {
+	return &LXCOSTplResource{}
+}
+
+// LXCOSTplResource defines the resource implementation.
+type LXCOSTplResource struct {
+	client *proxmox.Client
+}
+
+// LXCOSTplResourceModel describes the resource data model.
+type LXCOSTplResourceModel struct {
+	Cluster            types.String `tfsdk:"cluster"`
+	ID                 types.String `tfsdk:"id"`
+	Node               types.String `tfsdk:"node"`
+	Storage            types.String `tfsdk:"storage"`
+	Template           types.String `tfsdk:"template"`
+	URL                types.String `tfsdk:"url"`
+	FileName           types.String `tfsdk:"file_name"`
+	Checksum           types.String `tfsdk:"checksum"`
+	ChecksumAlgorithm  types.String `tfsdk:"checksum_algorithm"`
+	VerifyCertificates types.Bool   `tfsdk:"verify_certificates"`
+	VolID              types.String `tfsdk:"volid"`
+	Size               types.Int64  `tfsdk:"size"`
+}
+
+func (r *LXCOSTplResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "lxc_os_template"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *LXCOSTplResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	fixed := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	// the attributes with a provider default are replaced by
+	// ModifyPlan when their resolved value changes.
+	fixedIfConfigured := []planmodifier.String{
+		stringplanmodifier.RequiresReplaceIfConfigured(),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "LXC OS template resource",
+		Description:         DESC_LXC_OSTPL,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_LXC_OSTPL_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   DESC_LXC_OSTPL_NODE + " " + DESC_LXC_DFLT_NODE,
+				PlanModifiers: fixedIfConfigured,
+			},
+			"storage": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   DESC_LXC_OSTPL_STORAGE + " " + DESC_LXC_DFLT_STORAGE,
+				PlanModifiers: fixedIfConfigured,
+			},
+			"template": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_LXC_OSTPL_TEMPLATE,
+				PlanModifiers: fixed,
+			},
+			"url": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_LXC_OSTPL_URL,
+				PlanModifiers: fixed,
+			},
+			"file_name": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_LXC_OSTPL_FILE_NAME,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_LXC_OSTPL_CHECKSUM,
+				PlanModifiers: fixed,
+			},
+			"checksum_algorithm": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_LXC_OSTPL_CHECKSUM_ALG,
+				Validators: []validator.String{
+					validators.OneOf("md5", "sha1", "sha224", "sha256", "sha384", "sha512"),
+				},
+				PlanModifiers: fixed,
+			},
+			"verify_certificates": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_LXC_OSTPL_VERIFY_CERTS),
+				Description: DESC_LXC_OSTPL_VERIFY_CERTS,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"volid": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_LXC_OSTPL_VOLID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: DESC_LXC_OSTPL_SIZE,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *LXCOSTplResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig ensures exactly one template source is set, that
+// the url file name is a template one and that checksums come
+// along with their algorithm.
+func (r *LXCOSTplResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data LXCOSTplResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Template.IsUnknown() && !data.URL.IsUnknown() && data.Template.IsNull() == data.URL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("template"),
+			"Invalid Template Source",
+			"Exactly one of the template or url attributes must be set.",
+		)
+	}
+
+	if !data.Template.IsNull() && !data.FileName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("file_name"),
+			"Invalid Template File Name",
+			"Attribute file_name can only be used along with url, the template name is used as file name.",
+		)
+	}
+
+	if data.Checksum.IsNull() != data.ChecksumAlgorithm.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("checksum"),
+			"Invalid Template Checksum",
+			"Attributes checksum and checksum_algorithm must be set together.",
+		)
+	}
+
+	if !data.URL.IsNull() && !data.URL.IsUnknown() && !data.FileName.IsUnknown() {
+		attr := tfpath.Root("url")
+		if !data.FileName.IsNull() {
+			attr = tfpath.Root("file_name")
+		}
+
+		fileName, err := urlFileName(data.URL.ValueString(), data.FileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(attr, "Invalid Template File Name", err.Error())
+		} else if !osTemplateExtRe.MatchString(fileName) {
+			resp.Diagnostics.AddAttributeError(
+				attr,
+				"Invalid Template File Name",
+				fmt.Sprintf("The template file name must have a .tar.gz, .tar.xz, .tar.zst or .tar.bz2 extension, got: %q.", fileName),
+			)
+		}
+	}
+
+	alg := data.ChecksumAlgorithm.ValueString()
+	if !data.Template.IsNull() && alg != "" && alg != "md5" && alg != "sha512" {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("checksum_algorithm"),
+			"Invalid Template Checksum",
+			fmt.Sprintf("The appliance index only provides md5 and sha512 checksums, got: %q.", alg),
+		)
+	}
+}
+
+// ModifyPlan resolves the provider defaults of the attributes that
+// are not configured and reports the privileges missing to download
+// the template.
+func (r *LXCOSTplResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	defaults := providerDefaults(client)
+	planDefault(ctx, req, resp, tfpath.Root("node"), defaults.Node, true)
+	planRequired(ctx, req, resp, tfpath.Root("node"))
+	planDefault(ctx, req, resp, tfpath.Root("storage"), defaults.Storage, true)
+	planRequired(ctx, req, resp, tfpath.Root("storage"))
+
+	if preflight.Creates(req) {
+		if storage, ok := preflight.PlannedString(ctx, resp, tfpath.Root("storage")); ok {
+			preflight.Require(client, &resp.Diagnostics, "Datastore.AllocateTemplate", "/storage/"+storage)
+		}
+	}
+}
+
+func (r *LXCOSTplResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LXCOSTplResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := data.Node.ValueString()
+	storage := data.Storage.ValueString()
+
+	if !data.Template.IsNull() {
+		template := data.Template.ValueString()
+		if err := r.checkAppliance(ctx, client, data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to verify template %s, got error: %s", template, err))
+			return
+		}
+
+		if err := client.DownloadAppliance(ctx, node, storage, template); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download template %s, got error: %s", template, err))
+			return
+		}
+		data.FileName = types.StringValue(template)
+	} else {
+		fileName, err := urlFileName(data.URL.ValueString(), data.FileName)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Template URL", err.Error())
+			return
+		}
+
+		err = client.DownloadURL(ctx, proxmox.DownloadURLRequest{
+			Node:               node,
+			Storage:            storage,
+			Content:            "vztmpl",
+			Filename:           fileName,
+			URL:                data.URL.ValueString(),
+			Checksum:           data.Checksum.ValueStringPointer(),
+			ChecksumAlgorithm:  data.ChecksumAlgorithm.ValueStringPointer(),
+			VerifyCertificates: data.VerifyCertificates.ValueBoolPointer(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download template %s, got error: %s", data.URL.ValueString(), err))
+			return
+		}
+		data.FileName = types.StringValue(fileName)
+	}
+
+	data.VolID = types.StringValue(fmt.Sprintf("%s:vztmpl/%s", storage, data.FileName.ValueString()))
+	data.ID = data.VolID
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LXCOSTplResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LXCOSTplResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "template not found, removing it from state", map[string]any{"volid": data.VolID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only stores the plan, every attribute requires
+// the template to be replaced.
+func (r *LXCOSTplResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data LXCOSTplResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LXCOSTplResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LXCOSTplResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := client.DeleteStorageVolume(ctx, data.Node.ValueString(), data.Storage.ValueString(), data.VolID.ValueString())
+	if err != nil && !proxmox.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete template, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// read loads the remote template volume into data.
+func (r *LXCOSTplResource) read(ctx context.Context, client *proxmox.Client, data *LXCOSTplResourceModel) error {
+	volume, err := client.GetStorageVolume(ctx, data.Node.ValueString(), data.Storage.ValueString(), data.VolID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.Size = types.Int64Value(volume.Size)
+
+	return nil
+}
+
+// checkAppliance ensures the template exists in the appliance
+// index and, when set, that its checksum matches the expected one.
+func (r *LXCOSTplResource) checkAppliance(ctx context.Context, client *proxmox.Client, data LXCOSTplResourceModel) error {
+	appliances, err := client.GetAppliances(ctx, data.Node.ValueString())
+	if err != nil {
+		return err
+	}
+
+	template := data.Template.ValueString()
+	for _, appliance := range appliances {
+		if appliance.Template != template {
+			continue
+		}
+
+		if data.Checksum.IsNull() {
+			return nil
+		}
+
+		expected := appliance.SHA512Sum
+		if data.ChecksumAlgorithm.ValueString() == "md5" {
+			expected = appliance.MD5Sum
+		}
+		if !strings.EqualFold(expected, data.Checksum.ValueString()) {
+			return fmt.Errorf("checksum mismatch, the appliance index %s checksum is %q",
+				data.ChecksumAlgorithm.ValueString(), expected)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("template not found in the appliance index, run `pveam update` on the node to refresh it")
+}
+
+// osTemplateExtRe matches the container template extensions
+// proxmox stores in the vztmpl content.
+var osTemplateExtRe = regexp.MustCompile(`\.tar\.(gz|xz|zst|bz2)$`)
+
+// urlFileName returns the file name to download the url into,
+// the configured one or the last url path segment.
+func urlFileName(rawURL string, fileName types.String) (string, error) {
+	if !fileName.IsNull() && !fileName.IsUnknown() {
+		return fileName.ValueString(), nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return "", fmt.Errorf("unable to get the file name from url %q, set the file_name attribute", rawURL)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,108 @@
+package lxc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestURLFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		fileName types.String
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "last path segment",
+			url:      "https://example.com/images/debian-12.tar.zst",
+			fileName: types.StringNull(),
+			want:     "debian-12.tar.zst",
+		},
+		{
+			name:     "query ignored",
+			url:      "https://example.com/download/alpine.tar.xz?mirror=1#top",
+			fileName: types.StringNull(),
+			want:     "alpine.tar.xz",
+		},
+		{
+			name:     "escaped segment",
+			url:      "https://example.com/my%20template.tar.gz",
+			fileName: types.StringNull(),
+			want:     "my template.tar.gz",
+		},
+		{
+			name:     "trailing slash",
+			url:      "https://example.com/images/rocky.tar.xz/",
+			fileName: types.StringNull(),
+			want:     "rocky.tar.xz",
+		},
+		{
+			name:     "configured file name",
+			url:      "https://example.com/download?id=1",
+			fileName: types.StringValue("custom.tar.gz"),
+			want:     "custom.tar.gz",
+		},
+		{
+			name:     "unknown file name",
+			url:      "https://example.com/debian.tar.zst",
+			fileName: types.StringUnknown(),
+			want:     "debian.tar.zst",
+		},
+		{
+			name:     "no path",
+			url:      "https://example.com",
+			fileName: types.StringNull(),
+			wantErr:  true,
+		},
+		{
+			name:     "root path",
+			url:      "https://example.com/",
+			fileName: types.StringNull(),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid url",
+			url:      "https://example.com/%zz",
+			fileName: types.StringNull(),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := urlFileName(tt.url, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("urlFileName(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("urlFileName(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSTemplateExtRe(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     bool
+	}{
+		{"debian-12.tar.zst", true},
+		{"alpine.tar.xz", true},
+		{"centos.tar.gz", true},
+		{"old.tar.bz2", true},
+		{"image.tar", false},
+		{"image.tgz", false},
+		{"image.tar.zst.part", false},
+		{"image.qcow2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			if got := osTemplateExtRe.MatchString(tt.fileName); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -19,6 +23,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LXCResource{}
 var _ resource.ResourceWithImportState = &LXCResource{}
+var _ resource.ResourceWithModifyPlan = &LXCResource{}
 
 func NewLXCResource(name string) func() resource.Resource {
 	return func() resource.Resource {
@@ -28,7 +33,7 @@ func NewLXCResource(name string) func() resource.Resource {
 
 // LXCResource defines the resource implementation.
 type LXCResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 	name   string
 }
 
@@ -177,6 +182,7 @@ func (m LXCNetResourceModel) ToPVELXCNet() pve.LxcNet {
 // LXCResourceModel describes the resource data model.
 type LXCResourceModel struct {
 	// Create options
+	Cluster    types.String `tfsdk:"cluster"`
 	Node       types.String `tfsdk:"node"`
 	OSTemplate types.String `tfsdk:"os_template"`
 	VMID       types.Int64  `tfsdk:"id"`
@@ -194,8 +200,8 @@ type LXCResourceModel struct {
 
 	Features types.Object `tfsdk:"features"`
 	//Force              types.Bool   `tfsdk:"force"`
-	//Hookscript         types.String `tfsdk:"hookscript"`
-	Hostname types.String `tfsdk:"hostname"`
+	Hookscript types.String `tfsdk:"hookscript"`
+	Hostname   types.String `tfsdk:"hostname"`
 	//IgnoreUnpackErrors types.Bool   `tfsdk:"ignore_unpack_errors"`
 	//Lock   types.String `tfsdk:"lock"`
 	//Memory types.Int64 `tfsdk:"memory"`
@@ -272,11 +278,11 @@ func (r *LXCResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -285,6 +291,26 @@ func (r *LXCResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+// ModifyPlan resolves the provider defaults of the attributes that
+// are not configured and reports the privileges missing to create
+// the container.
+func (r *LXCResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	planLXCDefaults(ctx, client, req, resp)
+
+	if preflight.Creates(req) {
+		preflight.Require(client, &resp.Diagnostics, "VM.Allocate", plannedVMACLPath(ctx, resp, path.Root("id")))
+	}
+}
+
 func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data LXCResourceModel
 
@@ -294,13 +320,9 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	// if no vmid has been set, retrieve one from proxmox
-	vmid, err := getVMID(r.client, data.VMID)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %s", err))
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
-	} else {
-		tflog.Info(ctx, "got vmid from proxmox", map[string]any{"vmid": vmid})
 	}
 
 	// Format the ssh public keys to the go-proxmox format
@@ -310,7 +332,6 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 	apiReq := pve.CreateLxcRequest{
 		Node:          data.Node.ValueString(),
 		OSTemplate:    data.OSTemplate.ValueString(),
-		VMID:          vmid,
 		Hostname:      data.Hostname.ValueString(),
 		Password:      data.Password.ValueString(),
 		SSHPublicKeys: ssh,
@@ -339,22 +360,62 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 	apiReq.Net = newPVELXCNets(ctx, data.Networks)
 	tflog.Debug(ctx, "got networks", map[string]any{"networks": apiReq.Net})
 
-	// send lxc create request through api
-	if _, err := r.client.LXC.Create(apiReq); err != nil {
+	// send lxc create request through api, if no vmid has been
+	// set one is allocated within the provider vmid range.
+	vmid, err := createWithVMID(ctx, client, data.VMID, func(vmid int) error {
+		apiReq.VMID = vmid
+		return lockLXC(ctx, client, apiReq.Node, []int{vmid}, func() error {
+			var upid string
+			if err := client.RetryLocked(ctx, func() (err error) {
+				upid, err = client.LXC.Create(apiReq)
+				return err
+			}); err != nil {
+				return err
+			}
+			// the lxc is locked until its creation task stops.
+			return client.WaitTask(ctx, upid)
+		})
+	})
+	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %s", err.Error()))
 		return
 	} else {
-		tflog.Info(ctx, "lxc created", map[string]any{"vmid": vmid, "req": apiReq})
+		tflog.Info(ctx, "lxc created", map[string]any{"vmid": vmid, "req": redactLXCRequest(apiReq)})
 	}
 
 	// appends the current state after creation
 	data.VMID = types.Int64Value(int64(vmid))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
+	// go-proxmox does not support the hookscript, so it is set
+	// through the config before the lxc is started.
+	if hookscript := data.Hookscript.ValueStringPointer(); hookscript != nil {
+		if err := lockLXC(ctx, client, apiReq.Node, []int{vmid}, func() error {
+			return client.UpdateLXCConfig(ctx, proxmox.LXCConfigRequest{
+				Node:       apiReq.Node,
+				VMID:       vmid,
+				Hookscript: hookscript,
+			})
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set lxc hookscript, got error: %s", err))
+			if err := deleteLXC(
+				ctx,
+				client,
+				apiReq.Node,
+				vmid,
+			); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lxc, got error: %s", err.Error()))
+			} else {
+				resp.State.RemoveResource(ctx)
+			}
+			return
+		}
+	}
+
 	// Start or stop the lxc according to the configured status
 	err = updateLXCStatus(
 		ctx,
-		r.client,
+		client,
 		apiReq.Node,
 		vmid,
 		data.Status.ValueString(),
@@ -363,7 +424,7 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %s", err.Error()))
 		if err := deleteLXC(
 			ctx,
-			r.client,
+			client,
 			apiReq.Node,
 			vmid,
 		); err != nil {
@@ -380,12 +441,12 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Compute network ips only if lxc is running.
 	// At this point, we know the lxc desired status.
 	if data.Status.ValueString() == string(pve.LXC_STATUS_RUNNING) {
-		computedNets, err := computeLXCNetIPs(ctx, r.client, apiReq.Node, vmid, data.Networks)
+		computedNets, err := computeLXCNetIPs(ctx, client, apiReq.Node, vmid, data.Networks)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %s", err.Error()))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -410,7 +471,7 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 		// if the desiredStatus is stopped start the lxc
 		if err := updateLXCStatus(
 			ctx,
-			r.client,
+			client,
 			apiReq.Node,
 			vmid,
 			string(pve.LXC_STATUS_RUNNING),
@@ -418,7 +479,7 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to start node lxc, got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -430,11 +491,11 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 		}
 
 		// run commands
-		if err := runLXCCommands(ctx, r.client, vmid, data.CMDs); err != nil {
+		if err := runLXCCommands(ctx, client, vmid, data.CMDs); err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to run commands inside lxc , got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -447,7 +508,7 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 		if err := updateLXCStatus(
 			ctx,
-			r.client,
+			client,
 			apiReq.Node,
 			vmid,
 			data.Status.ValueString(),
@@ -455,7 +516,7 @@ func (r *LXCResource) Create(ctx context.Context, req resource.CreateRequest, re
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update node lxc status, got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -485,12 +546,21 @@ func (r *LXCResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	vmid := int(data.VMID.ValueInt64())
 	node := data.Node.ValueString()
 
 	desiredStatus := data.Status.ValueString()
 
-	remoteData, err := r.client.LXC.GetByID(node, vmid)
+	var remoteData *pve.LXC
+	err := client.Retry(ctx, func() (err error) {
+		remoteData, err = client.LXC.GetByID(node, vmid)
+		return err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node lxc, got error: %s", err))
 		return
@@ -504,7 +574,7 @@ func (r *LXCResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
 	if desiredStatus == string(pve.LXC_STATUS_RUNNING) {
-		computedNets, err := computeLXCNetIPs(ctx, r.client, node, vmid, data.Networks)
+		computedNets, err := computeLXCNetIPs(ctx, client, node, vmid, data.Networks)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lxc ifaces ips, got error: %s", err.Error()))
 		}
@@ -538,7 +608,12 @@ func (r *LXCResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	if err := updateLXCStatus(ctx, r.client, node, vmid, string(pve.LXC_STATUS_STOPPED)); err != nil {
+	client := clusterattr.Client(ctx, r.client, plan.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := updateLXCStatus(ctx, client, node, vmid, string(pve.LXC_STATUS_STOPPED)); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop lxc, got error: %s", err))
 		return
 	}
@@ -549,19 +624,23 @@ func (r *LXCResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		"planNetworks":  newLXCNetsResourceModel(ctx, plan.Networks),
 		"stateNetworks": newLXCNetsResourceModel(ctx, state.Networks),
 	})
-	if err := r.client.LXC.Update(pve.UpdateLxcRequest{
-		Node: state.Node.ValueString(),
-		VMID: vmid,
-		Net:  newPVELXCNets(ctx, plan.Networks),
+	if err := lockLXC(ctx, client, node, []int{vmid}, func() error {
+		return client.Retry(ctx, func() error {
+			return client.LXC.Update(pve.UpdateLxcRequest{
+				Node: state.Node.ValueString(),
+				VMID: vmid,
+				Net:  newPVELXCNets(ctx, plan.Networks),
+			})
+		})
 	}); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lxc interfaces, got error: %s", err))
 		return
 	}
-	if err := updateLXCStatus(ctx, r.client, node, vmid, status); err != nil {
+	if err := updateLXCStatus(ctx, client, node, vmid, status); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lxc status, got error: %s", err))
 		return
 	}
-	computedNets, err := computeLXCNetIPs(ctx, r.client, node, vmid, plan.Networks)
+	computedNets, err := computeLXCNetIPs(ctx, client, node, vmid, plan.Networks)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lxc ifaces ips, got error: %s", err.Error()))
 		return
@@ -585,9 +664,14 @@ func (r *LXCResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := deleteLXC(
 		ctx,
-		r.client,
+		client,
 		data.Node.ValueString(),
 		int(data.VMID.ValueInt64()),
 	); err != nil {
@@ -599,14 +683,16 @@ func (r *LXCResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *LXCResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	cluster, importID := clusterattr.ImportID(req.ID)
+	id, err := strconv.Atoi(importID)
 	if err != nil {
 		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import lxc, got error: %s", err))
 		return
 	}
 
 	state := LXCResourceModel{
-		VMID: basetypes.NewInt64Value(int64(id)),
+		Cluster: cluster,
+		VMID:    basetypes.NewInt64Value(int64(id)),
 	}
 
 	diags := resp.State.Set(ctx, &state)
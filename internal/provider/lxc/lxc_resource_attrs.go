@@ -1,6 +1,8 @@
 package lxc
 
 import (
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -15,11 +17,13 @@ import (
 
 func newLXCResourceAttrs() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
+		clusterattr.Name: clusterattr.ResourceAttribute(),
 		"node": schema.StringAttribute{
-			Description: DESC_LXC_NODE,
-			Required:    true,
+			Description: DESC_LXC_NODE + " " + DESC_LXC_DFLT_NODE,
+			Optional:    true,
+			Computed:    true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
 		},
 		"os_template": schema.StringAttribute{
@@ -122,14 +126,14 @@ func newLXCResourceAttrs() map[string]schema.Attribute {
 		//		boolplanmodifier.RequiresReplace(),
 		//	},
 		//},
-		//"hookscript": schema.StringAttribute{
-		//	Description: DESC_LXC_HOOK,
-		//	Optional:    true,
-		//	//Computed:    true,
-		//	PlanModifiers: []planmodifier.String{
-		//		stringplanmodifier.RequiresReplace(),
-		//	},
-		//},
+		"hookscript": schema.StringAttribute{
+			Description: DESC_LXC_HOOK,
+			Optional:    true,
+			//Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
 		"hostname": schema.StringAttribute{
 			Description: DESC_LXC_HOSTNAME,
 			Optional:    true,
@@ -164,10 +168,11 @@ func newLXCResourceAttrs() map[string]schema.Attribute {
 		//	},
 		//},
 		"nameserver": schema.StringAttribute{
-			Description: DESC_LXC_NS,
+			Description: DESC_LXC_NS + " " + DESC_LXC_DFLT_NS,
 			Optional:    true,
+			Computed:    true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
 		},
 		"networks": schema.ListNestedAttribute{
@@ -419,7 +424,9 @@ func newLXCNetResourceAttrs() map[string]schema.Attribute {
 			Required: true,
 		},
 		"bridge": schema.StringAttribute{
-			Optional: true,
+			Optional:    true,
+			Computed:    true,
+			Description: DESC_LXC_NET_BRIDGE + " " + DESC_LXC_DFLT_BRIDGE,
 		},
 		"firewall": schema.BoolAttribute{
 			Optional: true,
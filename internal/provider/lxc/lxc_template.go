@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -19,6 +23,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LXCTplResource{}
 var _ resource.ResourceWithImportState = &LXCTplResource{}
+var _ resource.ResourceWithModifyPlan = &LXCTplResource{}
 
 func NewLXCTplResource(name string) func() resource.Resource {
 	return func() resource.Resource {
@@ -28,13 +33,14 @@ func NewLXCTplResource(name string) func() resource.Resource {
 
 // LXCTplResource defines the resource implementation.
 type LXCTplResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 	name   string
 }
 
 // LXCTplResourceModel describes the resource data model.
 type LXCTplResourceModel struct {
 	// Create options
+	Cluster    types.String `tfsdk:"cluster"`
 	Node       types.String `tfsdk:"node"`
 	OSTemplate types.String `tfsdk:"os_template"`
 	VMID       types.Int64  `tfsdk:"id"`
@@ -52,8 +58,8 @@ type LXCTplResourceModel struct {
 
 	Features types.Object `tfsdk:"features"`
 	//Force              types.Bool   `tfsdk:"force"`
-	//Hookscript         types.String `tfsdk:"hookscript"`
-	Hostname types.String `tfsdk:"hostname"`
+	Hookscript types.String `tfsdk:"hookscript"`
+	Hostname   types.String `tfsdk:"hostname"`
 	//IgnoreUnpackErrors types.Bool   `tfsdk:"ignore_unpack_errors"`
 	//Lock   types.String `tfsdk:"lock"`
 	//Memory types.Int64 `tfsdk:"memory"`
@@ -129,11 +135,11 @@ func (r *LXCTplResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -142,6 +148,26 @@ func (r *LXCTplResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan resolves the provider defaults of the attributes that
+// are not configured and reports the privileges missing to create
+// the container.
+func (r *LXCTplResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	planLXCDefaults(ctx, client, req, resp)
+
+	if preflight.Creates(req) {
+		preflight.Require(client, &resp.Diagnostics, "VM.Allocate", plannedVMACLPath(ctx, resp, path.Root("id")))
+	}
+}
+
 func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data LXCTplResourceModel
 
@@ -151,13 +177,9 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// if no vmid has been set, retrieve one from proxmox
-	vmid, err := getVMID(r.client, data.VMID)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %#v", err))
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
-	} else {
-		tflog.Info(ctx, "got vmid from proxmox", map[string]any{"vmid": vmid})
 	}
 
 	// Format the ssh public keys to the go-proxmox format
@@ -167,7 +189,6 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 	apiReq := pve.CreateLxcRequest{
 		Node:          data.Node.ValueString(),
 		OSTemplate:    data.OSTemplate.ValueString(),
-		VMID:          vmid,
 		Hostname:      data.Hostname.ValueString(),
 		Password:      data.Password.ValueString(),
 		SSHPublicKeys: ssh,
@@ -195,18 +216,58 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 	// set networks to api request
 	apiReq.Net = newPVELXCTplNets(ctx, data.Networks)
 
-	// send lxc create request through api
-	if _, err := r.client.LXC.Create(apiReq); err != nil {
+	// send lxc create request through api, if no vmid has been
+	// set one is allocated within the provider vmid range.
+	vmid, err := createWithVMID(ctx, client, data.VMID, func(vmid int) error {
+		apiReq.VMID = vmid
+		return lockLXC(ctx, client, apiReq.Node, []int{vmid}, func() error {
+			var upid string
+			if err := client.RetryLocked(ctx, func() (err error) {
+				upid, err = client.LXC.Create(apiReq)
+				return err
+			}); err != nil {
+				return err
+			}
+			// the lxc is locked until its creation task stops.
+			return client.WaitTask(ctx, upid)
+		})
+	})
+	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node lxc, got error: %v", err))
 		return
 	} else {
-		tflog.Info(ctx, "lxc created", map[string]any{"vmid": vmid, "req": apiReq})
+		tflog.Info(ctx, "lxc created", map[string]any{"vmid": vmid, "req": redactLXCRequest(apiReq)})
 	}
 
 	// appends the current state after creation
 	data.VMID = types.Int64Value(int64(vmid))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
+	// go-proxmox does not support the hookscript, so it is set
+	// through the config before the lxc is started.
+	if hookscript := data.Hookscript.ValueStringPointer(); hookscript != nil {
+		if err := lockLXC(ctx, client, apiReq.Node, []int{vmid}, func() error {
+			return client.UpdateLXCConfig(ctx, proxmox.LXCConfigRequest{
+				Node:       apiReq.Node,
+				VMID:       vmid,
+				Hookscript: hookscript,
+			})
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set lxc hookscript, got error: %s", err))
+			if err := deleteLXC(
+				ctx,
+				client,
+				apiReq.Node,
+				vmid,
+			); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lxc, got error: %s", err.Error()))
+			} else {
+				resp.State.RemoveResource(ctx)
+			}
+			return
+		}
+	}
+
 	// run commands in lxc
 	// if desired status is running, simply run the commands
 	// if desired status is stopped, start -> run cmds -> stop
@@ -214,7 +275,7 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 		// start the lxc to run the commands
 		if err := updateLXCStatus(
 			ctx,
-			r.client,
+			client,
 			apiReq.Node,
 			vmid,
 			string(pve.LXC_STATUS_RUNNING),
@@ -222,7 +283,7 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to start lxc to run commands, got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -234,11 +295,11 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 
 		// run commands
-		if err := runLXCCommands(ctx, r.client, vmid, data.CMDs); err != nil {
+		if err := runLXCCommands(ctx, client, vmid, data.CMDs); err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to run commands inside lxc , got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -252,7 +313,7 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 		// stop the lxc after commands are run
 		if err := updateLXCStatus(
 			ctx,
-			r.client,
+			client,
 			apiReq.Node,
 			vmid,
 			string(pve.LXC_STATUS_STOPPED),
@@ -260,7 +321,7 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update node lxc status, got error: %s", err))
 			if err := deleteLXC(
 				ctx,
-				r.client,
+				client,
 				apiReq.Node,
 				vmid,
 			); err != nil {
@@ -273,7 +334,11 @@ func (r *LXCTplResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Convert the lxc to a template
-	if err := r.client.LXC.CreateTemplate(apiReq.Node, vmid); err != nil {
+	if err := lockLXC(ctx, client, apiReq.Node, []int{vmid}, func() error {
+		return client.RetryLocked(ctx, func() error {
+			return client.LXC.CreateTemplate(apiReq.Node, vmid)
+		})
+	}); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to convert lxc to template, got error: %s", err.Error()))
 		return
 	}
@@ -309,9 +374,14 @@ func (r *LXCTplResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := deleteLXC(
 		ctx,
-		r.client,
+		client,
 		data.Node.ValueString(),
 		int(data.VMID.ValueInt64()),
 	); err != nil {
@@ -321,14 +391,16 @@ func (r *LXCTplResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *LXCTplResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	id, err := strconv.Atoi(req.ID)
+	cluster, importID := clusterattr.ImportID(req.ID)
+	id, err := strconv.Atoi(importID)
 	if err != nil {
 		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import lxc template, got error: %s", err))
 		return
 	}
 
 	state := LXCTplResourceModel{
-		VMID: basetypes.NewInt64Value(int64(id)),
+		Cluster: cluster,
+		VMID:    basetypes.NewInt64Value(int64(id)),
 	}
 
 	diags := resp.State.Set(ctx, &state)
@@ -1,6 +1,8 @@
 package lxc
 
 import (
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -13,11 +15,13 @@ import (
 
 func newLXCTplResourceAttrs() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
+		clusterattr.Name: clusterattr.ResourceAttribute(),
 		"node": schema.StringAttribute{
-			Description: DESC_LXC_NODE,
-			Required:    true,
+			Description: DESC_LXC_NODE + " " + DESC_LXC_DFLT_NODE,
+			Optional:    true,
+			Computed:    true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
 		},
 		"os_template": schema.StringAttribute{
@@ -120,14 +124,14 @@ func newLXCTplResourceAttrs() map[string]schema.Attribute {
 		//		boolplanmodifier.RequiresReplace(),
 		//	},
 		//},
-		//"hookscript": schema.StringAttribute{
-		//	Description: DESC_LXC_HOOK,
-		//	Optional:    true,
-		//	//Computed:    true,
-		//	PlanModifiers: []planmodifier.String{
-		//		stringplanmodifier.RequiresReplace(),
-		//	},
-		//},
+		"hookscript": schema.StringAttribute{
+			Description: DESC_LXC_HOOK,
+			Optional:    true,
+			//Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
 		"hostname": schema.StringAttribute{
 			Description: DESC_LXC_HOSTNAME,
 			Optional:    true,
@@ -162,10 +166,11 @@ func newLXCTplResourceAttrs() map[string]schema.Attribute {
 		//	},
 		//},
 		"nameserver": schema.StringAttribute{
-			Description: DESC_LXC_NS,
+			Description: DESC_LXC_NS + " " + DESC_LXC_DFLT_NS,
 			Optional:    true,
+			Computed:    true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
 		},
 		"networks": schema.ListNestedAttribute{
@@ -411,7 +416,9 @@ func newLxcTplNetResourceAttrs() map[string]schema.Attribute {
 			Required: true,
 		},
 		"bridge": schema.StringAttribute{
-			Optional: true,
+			Optional:    true,
+			Computed:    true,
+			Description: DESC_LXC_NET_BRIDGE + " " + DESC_LXC_DFLT_BRIDGE,
 		},
 		"firewall": schema.BoolAttribute{
 			Optional: true,
@@ -53,7 +53,9 @@ const DESC_LXC_FEATS = "Allow containers access to advanced features."
 // TODO: Add features descriptions
 const DESC_LXC_FORCE = "Allow to overwrite existing container."
 const DESC_LXC_HOOK = "Script that will be executed during various " +
-	"steps in the containers lifetime."
+	"steps in the containers lifetime. It must be the volume ID " +
+	"of an executable snippet, i.e. local:snippets/hook.sh, which " +
+	"can be uploaded with a proxmox_storage_file."
 const DESC_LXC_HOSTNAME = "Set a host name for the container."
 const DESC_LXC_IGNERR = "Ignore errors when extracting the template."
 const DESC_LXC_LOCK = "Lock/unlock the container.\n" +
@@ -68,6 +70,8 @@ const DESC_LXC_NS = "Sets DNS server IP address for a container. " +
 	"if you neither set searchdomain nor nameserver."
 const DESC_LXC_NET = "Specifies network interface for the container."
 const DFLT_LXC_NET_FW = false
+const DESC_LXC_NET_BRIDGE = "Bridge to attach the network interface to. " +
+	"Either a node bridge (i.e. 'vmbr0') or an sdn vnet name."
 const DESC_LXC_ONBOOT = "Specifies whether a container will be " +
 	"started during system bootup."
 const DFLT_LXC_ONBOOT = false
@@ -80,6 +84,11 @@ const DESC_LXC_OSTYPE = "OS type. This is used to setup " +
 	"opensuse | archlinux | alpine | gentoo | nixos | unmanaged"
 const DESC_LXC_PWD = "Sets root password inside container."
 const DESC_LXC_POOL = "Add the VM to the specified pool."
+const DESC_LXC_DFLT_NODE = "Defaults to the provider defaults node."
+const DESC_LXC_DFLT_STORAGE = "Defaults to the provider defaults storage."
+const DESC_LXC_DFLT_POOL = "Defaults to the provider defaults pool."
+const DESC_LXC_DFLT_BRIDGE = "Defaults to the provider defaults bridge."
+const DESC_LXC_DFLT_NS = "Defaults to the provider defaults nameserver."
 const DESC_LXC_PROTECTON = "Sets the protection flag of the container." +
 	"This will prevent the CT or CT's disk remove/update " +
 	"operation."
@@ -128,3 +137,42 @@ const DESC_LXC_IP = "LXC assigned ip."
 const DESC_LXC_CMDS = "List of commands to be executed after lxc " +
 	"creation using bash. If any command fail, the creation " +
 	"will also fail."
+
+const DESC_LXC_OSTPL = "Container OS template downloaded into a node " +
+	"storage, either from the appliance index (pveam) or from an url. " +
+	"The volid can be used as an lxc os_template."
+const DESC_LXC_OSTPL_ID = "The template volume ID."
+const DESC_LXC_OSTPL_NODE = "The node the template is downloaded on."
+const DESC_LXC_OSTPL_STORAGE = "The storage the template is " +
+	"downloaded into, it must allow the vztmpl content."
+const DESC_LXC_OSTPL_TEMPLATE = "The appliance index template name, " +
+	"i.e. debian-12-standard_12.7-1_amd64.tar.zst. Conflicts with url."
+const DESC_LXC_OSTPL_URL = "The url to download the template from. " +
+	"Conflicts with template."
+const DESC_LXC_OSTPL_FILE_NAME = "The template file name. Defaults to " +
+	"the template name or to the last url path segment, it must " +
+	"have a .tar.gz, .tar.xz, .tar.zst or .tar.bz2 extension."
+const DESC_LXC_OSTPL_CHECKSUM = "The expected template checksum. It is " +
+	"verified by proxmox for url downloads and compared against the " +
+	"appliance index for template downloads."
+const DESC_LXC_OSTPL_CHECKSUM_ALG = "The checksum algorithm.\n" +
+	"Values: md5 | sha1 | sha224 | sha256 | sha384 | sha512"
+const DESC_LXC_OSTPL_VERIFY_CERTS = "Verify the url server certificate."
+const DFLT_LXC_OSTPL_VERIFY_CERTS = true
+const DESC_LXC_OSTPL_VOLID = "The template volume ID, to be used as " +
+	"lxc os_template."
+const DESC_LXC_OSTPL_SIZE = "The template size in bytes."
+
+const DESC_DS_LXC = "Reads an existing container, looked up by " +
+	"id or by hostname, optionally scoped to a node."
+const DESC_DS_LXC_ID = "The container vmid. Either id or hostname " +
+	"must be set."
+const DESC_DS_LXC_HOSTNAME = "The container hostname. It must match " +
+	"a single container."
+const DESC_DS_LXC_NODE = "The node the container runs on. When set, " +
+	"only the containers of this node are looked up."
+const DESC_DS_LXC_STATUS = "LXC Container status.\n" +
+	"Values: stopped | running"
+const DESC_DS_LXC_DISK_SIZE = "The root disk size in GiB."
+const DESC_DS_LXC_NET_IP = "The interface IPv4 address (with prefix) " +
+	"reported by the running container."
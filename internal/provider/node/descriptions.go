@@ -0,0 +1,37 @@
+package node
+
+// descriptions for nodes
+const (
+	DESC_NODES                 = "The cluster nodes, sorted by name."
+	DESC_NODES_NODES           = "The cluster nodes."
+	DESC_NODES_NAME            = "The node name."
+	DESC_NODES_ONLINE          = "Whether the node is online."
+	DESC_NODES_CPU             = "The cpu usage, from 0 to 1."
+	DESC_NODES_MAX_CPU         = "The number of cpus."
+	DESC_NODES_MEM             = "The used memory in bytes."
+	DESC_NODES_MAX_MEM         = "The total memory in bytes."
+	DESC_NODES_UPTIME          = "The node uptime in seconds."
+	DESC_NODES_SSL_FINGERPRINT = "The sha256 fingerprint of the node certificate."
+)
+
+// descriptions for node status
+const (
+	DESC_STATUS              = "The status of a cluster node."
+	DESC_STATUS_NODE         = "The node name."
+	DESC_STATUS_KERNEL       = "The running kernel release."
+	DESC_STATUS_KVERSION     = "The full running kernel version."
+	DESC_STATUS_PVE_VERSION  = "The proxmox manager version, i.e. pve-manager/8.2.4/faa83925c9641325."
+	DESC_STATUS_LOAD_AVERAGE = "The 1, 5 and 15 minutes load averages."
+	DESC_STATUS_CPU          = "The cpu usage, from 0 to 1."
+	DESC_STATUS_UPTIME       = "The node uptime in seconds."
+	DESC_STATUS_MEMORY       = "The memory usage in bytes."
+	DESC_STATUS_SWAP         = "The swap usage in bytes."
+	DESC_STATUS_ROOTFS       = "The root file system usage in bytes."
+	DESC_STATUS_USAGE_TOTAL  = "Total bytes."
+	DESC_STATUS_USAGE_USED   = "Used bytes."
+	DESC_STATUS_USAGE_FREE   = "Free bytes."
+	DESC_STATUS_CPU_MODEL    = "The cpu model name."
+	DESC_STATUS_CPU_SOCKETS  = "The number of cpu sockets."
+	DESC_STATUS_CPU_CORES    = "The number of cpu cores per socket."
+	DESC_STATUS_CPU_THREADS  = "The number of logical cpus."
+)
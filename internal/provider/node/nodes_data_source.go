@@ -0,0 +1,149 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &nodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &nodesDataSource{}
+)
+
+// NewNodesDataSource is a helper function to simplify the provider implementation.
+func NewNodesDataSource() datasource.DataSource {
+	return &nodesDataSource{}
+}
+
+// nodesDataSource is the data source implementation.
+type nodesDataSource struct {
+	client *proxmox.Client
+}
+
+// nodesDataSourceModel describes the data source data model.
+type nodesDataSourceModel struct {
+	Cluster types.String `tfsdk:"cluster"`
+	Nodes   []nodeModel  `tfsdk:"nodes"`
+}
+
+// nodeModel describes a cluster node.
+type nodeModel struct {
+	Name           types.String  `tfsdk:"name"`
+	Online         types.Bool    `tfsdk:"online"`
+	CPU            types.Float64 `tfsdk:"cpu"`
+	MaxCPU         types.Int64   `tfsdk:"maxcpu"`
+	Mem            types.Int64   `tfsdk:"mem"`
+	MaxMem         types.Int64   `tfsdk:"maxmem"`
+	Uptime         types.Int64   `tfsdk:"uptime"`
+	SSLFingerprint types.String  `tfsdk:"ssl_fingerprint"`
+}
+
+// Metadata returns the data source type name.
+func (d *nodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "nodes"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *nodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nodeSchema := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name":            schema.StringAttribute{Computed: true, Description: DESC_NODES_NAME},
+			"online":          schema.BoolAttribute{Computed: true, Description: DESC_NODES_ONLINE},
+			"cpu":             schema.Float64Attribute{Computed: true, Description: DESC_NODES_CPU},
+			"maxcpu":          schema.Int64Attribute{Computed: true, Description: DESC_NODES_MAX_CPU},
+			"mem":             schema.Int64Attribute{Computed: true, Description: DESC_NODES_MEM},
+			"maxmem":          schema.Int64Attribute{Computed: true, Description: DESC_NODES_MAX_MEM},
+			"uptime":          schema.Int64Attribute{Computed: true, Description: DESC_NODES_UPTIME},
+			"ssl_fingerprint": schema.StringAttribute{Computed: true, Description: DESC_NODES_SSL_FINGERPRINT},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: DESC_NODES,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"nodes": schema.ListNestedAttribute{
+				NestedObject: nodeSchema,
+				Computed:     true,
+				Description:  DESC_NODES_NODES,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *nodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state nodesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "reading nodes")
+
+	nodes, err := client.GetNodes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox Nodes",
+			err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Node < nodes[j].Node
+	})
+
+	state.Nodes = []nodeModel{}
+	for _, n := range nodes {
+		state.Nodes = append(state.Nodes, nodeModel{
+			Name:           types.StringValue(n.Node),
+			Online:         types.BoolValue(n.Status == "online"),
+			CPU:            types.Float64Value(n.CPU),
+			MaxCPU:         types.Int64Value(n.MaxCPU),
+			Mem:            types.Int64Value(n.Mem),
+			MaxMem:         types.Int64Value(n.MaxMem),
+			Uptime:         types.Int64Value(n.Uptime),
+			SSLFingerprint: types.StringValue(n.SSLFingerprint),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,190 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &statusDataSource{}
+	_ datasource.DataSourceWithConfigure = &statusDataSource{}
+)
+
+// NewStatusDataSource is a helper function to simplify the provider implementation.
+func NewStatusDataSource() datasource.DataSource {
+	return &statusDataSource{}
+}
+
+// statusDataSource is the data source implementation.
+type statusDataSource struct {
+	client *proxmox.Client
+}
+
+// statusDataSourceModel describes the data source data model.
+type statusDataSourceModel struct {
+	Cluster     types.String  `tfsdk:"cluster"`
+	Node        types.String  `tfsdk:"node"`
+	Kernel      types.String  `tfsdk:"kernel"`
+	KVersion    types.String  `tfsdk:"kversion"`
+	PVEVersion  types.String  `tfsdk:"pveversion"`
+	LoadAverage types.List    `tfsdk:"load_average"`
+	CPU         types.Float64 `tfsdk:"cpu"`
+	Uptime      types.Int64   `tfsdk:"uptime"`
+	Memory      usageModel    `tfsdk:"memory"`
+	Swap        usageModel    `tfsdk:"swap"`
+	RootFS      usageModel    `tfsdk:"rootfs"`
+	CPUModel    types.String  `tfsdk:"cpu_model"`
+	CPUSockets  types.Int64   `tfsdk:"cpu_sockets"`
+	CPUCores    types.Int64   `tfsdk:"cpu_cores"`
+	CPUThreads  types.Int64   `tfsdk:"cpu_threads"`
+}
+
+// usageModel describes the usage of a node resource.
+type usageModel struct {
+	Total types.Int64 `tfsdk:"total"`
+	Used  types.Int64 `tfsdk:"used"`
+	Free  types.Int64 `tfsdk:"free"`
+}
+
+func newUsageModel(usage proxmox.NodeUsage) usageModel {
+	return usageModel{
+		Total: types.Int64Value(usage.Total),
+		Used:  types.Int64Value(usage.Used),
+		Free:  types.Int64Value(usage.Free),
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *statusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "node_status"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *statusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	usage := func(description string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: description,
+			Attributes: map[string]schema.Attribute{
+				"total": schema.Int64Attribute{Computed: true, Description: DESC_STATUS_USAGE_TOTAL},
+				"used":  schema.Int64Attribute{Computed: true, Description: DESC_STATUS_USAGE_USED},
+				"free":  schema.Int64Attribute{Computed: true, Description: DESC_STATUS_USAGE_FREE},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: DESC_STATUS,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"node": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_STATUS_NODE,
+			},
+			"kernel":     schema.StringAttribute{Computed: true, Description: DESC_STATUS_KERNEL},
+			"kversion":   schema.StringAttribute{Computed: true, Description: DESC_STATUS_KVERSION},
+			"pveversion": schema.StringAttribute{Computed: true, Description: DESC_STATUS_PVE_VERSION},
+			"load_average": schema.ListAttribute{
+				ElementType: types.Float64Type,
+				Computed:    true,
+				Description: DESC_STATUS_LOAD_AVERAGE,
+			},
+			"cpu":         schema.Float64Attribute{Computed: true, Description: DESC_STATUS_CPU},
+			"uptime":      schema.Int64Attribute{Computed: true, Description: DESC_STATUS_UPTIME},
+			"memory":      usage(DESC_STATUS_MEMORY),
+			"swap":        usage(DESC_STATUS_SWAP),
+			"rootfs":      usage(DESC_STATUS_ROOTFS),
+			"cpu_model":   schema.StringAttribute{Computed: true, Description: DESC_STATUS_CPU_MODEL},
+			"cpu_sockets": schema.Int64Attribute{Computed: true, Description: DESC_STATUS_CPU_SOCKETS},
+			"cpu_cores":   schema.Int64Attribute{Computed: true, Description: DESC_STATUS_CPU_CORES},
+			"cpu_threads": schema.Int64Attribute{Computed: true, Description: DESC_STATUS_CPU_THREADS},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *statusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state statusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "reading node status", map[string]any{"node": state.Node.ValueString()})
+
+	status, err := client.GetNodeStatus(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox Node Status",
+			err.Error(),
+		)
+		return
+	}
+
+	loads := []attr.Value{}
+	for _, load := range status.LoadAvg {
+		value, err := strconv.ParseFloat(load, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to parse proxmox node status",
+				fmt.Sprintf("Expected a numeric load average, but got %q", load),
+			)
+			return
+		}
+		loads = append(loads, types.Float64Value(value))
+	}
+
+	state.Kernel = types.StringValue(status.CurrentKernel.Release)
+	state.KVersion = types.StringValue(status.KVersion)
+	state.PVEVersion = types.StringValue(status.PVEVersion)
+	state.LoadAverage = types.ListValueMust(types.Float64Type, loads)
+	state.CPU = types.Float64Value(status.CPU)
+	state.Uptime = types.Int64Value(status.Uptime)
+	state.Memory = newUsageModel(status.Memory)
+	state.Swap = newUsageModel(status.Swap)
+	state.RootFS = newUsageModel(status.RootFS)
+	state.CPUModel = types.StringValue(status.CPUInfo.Model)
+	state.CPUSockets = types.Int64Value(status.CPUInfo.Sockets)
+	state.CPUCores = types.Int64Value(status.CPUInfo.Cores)
+	state.CPUThreads = types.Int64Value(status.CPUInfo.CPUs)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *statusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -7,7 +7,12 @@ const (
 		"the pve node firewall must be enabled " +
 		"otherwise the rule will be created but " +
 		"it will not take effect."
-	DESC_RULES     = "Array of rules.\n Rule: " + DESC_RULE
+	DESC_RULES           = "Array of rules.\n Rule: " + DESC_RULE
+	DESC_RULES_EXCLUSIVE = "Handling of the node rules not managed by " +
+		"the resource, i.e. created outside terraform or by " +
+		"proxmox_node_firewall_rule resources.\n" +
+		"Values: off (ignored) | report (a warning is raised) " +
+		"| remove (deleted on apply)"
 	DESC_RULE_NODE = "The cluster node name."
 	DESC_RULE_ID   = "go-proxmox generated id that lives " +
 		"within the rule comment field in proxmox."
@@ -66,3 +71,10 @@ const (
 	DESC_RULE_TYPE = "Rule type.\n" +
 		"Values: in | out | forward | group"
 )
+
+// exclusive modes of the rules resource.
+const (
+	EXCLUSIVE_OFF    = "off"
+	EXCLUSIVE_REPORT = "report"
+	EXCLUSIVE_REMOVE = "remove"
+)
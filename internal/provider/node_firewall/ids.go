@@ -0,0 +1,375 @@
+package nodefirewall
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/iolave/go-proxmox/pkg/pve"
+)
+
+// splitRuleComment splits a proxmox rule comment into the
+// go-proxmox id prefix and the descriptive comment, i.e.
+// "[id] comment". ok is false when the comment has no prefix.
+func splitRuleComment(comment string) (id string, text string, ok bool) {
+	if !strings.HasPrefix(comment, "[") {
+		return "", comment, false
+	}
+
+	idx := strings.IndexRune(comment, ']')
+	if idx < 2 {
+		return "", comment, false
+	}
+
+	id = comment[1:idx]
+	text = strings.TrimPrefix(comment[idx+1:], " ")
+	return id, text, true
+}
+
+// formatRuleComment prefixes the comment with the rule id,
+// the same way go-proxmox does.
+func formatRuleComment(id, text string) string {
+	return fmt.Sprintf("[%s] %s", id, text)
+}
+
+// newRuleID generates a random (v4 uuid formatted) rule id.
+func newRuleID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// ruleFingerprint identifies a rule by its content. It is
+// used to match rules that do not carry a go-proxmox id, i.e.
+// rules created outside terraform.
+func ruleFingerprint(typ, action, macro, iface, source, dest, proto, sport, dport, icmpType, log string, enable bool, comment string) string {
+	if log == "nolog" {
+		log = ""
+	}
+
+	return strings.Join([]string{
+		typ, action, macro, iface, source, dest, proto,
+		sport, dport, icmpType, log, fmt.Sprint(enable), comment,
+	}, "\x00")
+}
+
+// fingerprint returns the rule model content fingerprint.
+func (m ruleModel) fingerprint() string {
+	return ruleFingerprint(
+		m.Type.ValueString(),
+		m.Action.ValueString(),
+		m.Macro.ValueString(),
+		m.Interface.ValueString(),
+		m.Source.ValueString(),
+		m.Destination.ValueString(),
+		m.Proto.ValueString(),
+		m.Sport.ValueString(),
+		m.DestinationPort.ValueString(),
+		m.ICMPType.ValueString(),
+		m.LogLevel.ValueString(),
+		m.Enable.ValueBool(),
+		m.Comment.ValueString(),
+	)
+}
+
+// loadRemote loads the remote rule properties into the model.
+// The id is left untouched.
+func (m *ruleModel) loadRemote(rule proxmox.NodeFirewallRule) {
+	_, comment, _ := splitRuleComment(rule.Comment)
+
+	m.Action = types.StringValue(rule.Action)
+	m.Comment = types.StringValue(comment)
+	m.Destination = types.StringValue(rule.Dest)
+	m.DestinationPort = types.StringValue(rule.Dport)
+	m.Enable = types.BoolValue(rule.Enable == 1)
+	m.ICMPType = types.StringValue(rule.ICMPType)
+	m.Interface = types.StringValue(rule.Iface)
+	m.IPVersion = types.Int64Value(int64(rule.IPVersion))
+	m.LogLevel = types.StringValue(rule.Log)
+	m.Macro = types.StringValue(rule.Macro)
+	// FIXME: POS NOT WORKING
+	//m.Pos = types.Int64Value(int64(rule.Pos))
+	m.Proto = types.StringValue(rule.Proto)
+	m.Source = types.StringValue(rule.Source)
+	m.Sport = types.StringValue(rule.Sport)
+	m.Type = types.StringValue(rule.Type)
+}
+
+func remoteRuleFingerprint(rule proxmox.NodeFirewallRule) string {
+	_, comment, _ := splitRuleComment(rule.Comment)
+
+	return ruleFingerprint(
+		rule.Type, rule.Action, rule.Macro, rule.Iface, rule.Source,
+		rule.Dest, rule.Proto, rule.Sport, rule.Dport, rule.ICMPType,
+		rule.Log, rule.Enable == 1, comment,
+	)
+}
+
+// lockRules runs fn holding the node firewall rules lock. The
+// rules are changed by position, so the resources changing the
+// rules of a node must not run concurrently.
+func lockRules(ctx context.Context, c *proxmox.Client, node string, fn func() error) error {
+	return c.Locks.Do(ctx, "", []string{"firewall/" + node}, fn)
+}
+
+// remoteRules is a snapshot of the node firewall rules used to
+// resolve the rules managed by the resources. Each remote rule
+// can be claimed once, which allows telling apart the rules
+// that are not managed by a resource.
+//
+// The changes are sent with the snapshot digest, proxmox rejects
+// them when the rules were changed in the meantime.
+type remoteRules struct {
+	c       *proxmox.Client
+	node    string
+	rules   []proxmox.NodeFirewallRule
+	claimed map[int]bool
+	digest  string
+}
+
+func getRemoteRules(ctx context.Context, c *proxmox.Client, node string) (*remoteRules, error) {
+	rules, err := c.GetNodeFirewallRules(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteRules{c: c, node: node, rules: rules, claimed: map[int]bool{}, digest: rulesDigest(rules)}, nil
+}
+
+// rulesDigest returns the digest of the node rules, proxmox
+// returns it with each of them.
+func rulesDigest(rules []proxmox.NodeFirewallRule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	return rules[0].Digest
+}
+
+// refreshDigest reads the digest of the rules once they were
+// changed through the snapshot. The snapshot positions are kept,
+// the changes are made in an order that keeps them valid.
+func (s *remoteRules) refreshDigest(ctx context.Context) error {
+	rules, err := s.c.GetNodeFirewallRules(ctx, s.node)
+	if err != nil {
+		return err
+	}
+
+	s.digest = rulesDigest(rules)
+	return nil
+}
+
+// claim returns the rule with the given id. When no rule has
+// the id, it falls back to a rule without id that matches the
+// fingerprint (i.e. the id prefix was removed from the comment).
+func (s *remoteRules) claim(id string, fingerprint string) (proxmox.NodeFirewallRule, bool) {
+	for i, rule := range s.rules {
+		if s.claimed[i] || id == "" {
+			continue
+		}
+		if ruleID, _, ok := splitRuleComment(rule.Comment); ok && ruleID == id {
+			s.claimed[i] = true
+			return rule, true
+		}
+	}
+
+	return s.match(fingerprint)
+}
+
+// match returns the first unclaimed rule without id that
+// matches the fingerprint.
+func (s *remoteRules) match(fingerprint string) (proxmox.NodeFirewallRule, bool) {
+	for i, rule := range s.rules {
+		if s.claimed[i] {
+			continue
+		}
+		if _, _, ok := splitRuleComment(rule.Comment); ok {
+			continue
+		}
+		if remoteRuleFingerprint(rule) == fingerprint {
+			s.claimed[i] = true
+			return rule, true
+		}
+	}
+
+	return proxmox.NodeFirewallRule{}, false
+}
+
+// unclaimed returns the rules that were not claimed.
+func (s *remoteRules) unclaimed() []proxmox.NodeFirewallRule {
+	rules := []proxmox.NodeFirewallRule{}
+	for i, rule := range s.rules {
+		if !s.claimed[i] {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// adopt writes a new go-proxmox id into the rule comment and
+// returns it.
+func (s *remoteRules) adopt(ctx context.Context, rule proxmox.NodeFirewallRule) (string, error) {
+	id, err := newRuleID()
+	if err != nil {
+		return "", err
+	}
+
+	comment := formatRuleComment(id, rule.Comment)
+	if err := s.c.UpdateNodeFirewallRule(ctx, proxmox.UpdateNodeFirewallRuleRequest{
+		Node:    s.node,
+		Pos:     rule.Pos,
+		Comment: &comment,
+		Digest:  s.digest,
+	}); err != nil {
+		return "", err
+	}
+	tflog.Info(ctx, "adopted node firewall rule", map[string]any{"node": s.node, "pos": rule.Pos, "id": id})
+
+	return id, s.refreshDigest(ctx)
+}
+
+// update changes the rule in place to the planned content,
+// the id is written into its comment.
+func (s *remoteRules) update(ctx context.Context, rule proxmox.NodeFirewallRule, id string, m ruleModel) error {
+	action := m.Action.ValueString()
+	typ := m.Type.ValueString()
+	comment := formatRuleComment(id, m.Comment.ValueString())
+	enable := m.Enable.ValueBool()
+	req := proxmox.UpdateNodeFirewallRuleRequest{
+		Node:    s.node,
+		Pos:     rule.Pos,
+		Action:  &action,
+		Type:    &typ,
+		Comment: &comment,
+		Enable:  &enable,
+		Digest:  s.digest,
+	}
+
+	params := []struct {
+		name    string
+		planned string
+		current string
+		field   **string
+	}{
+		{"dest", m.Destination.ValueString(), rule.Dest, &req.Dest},
+		{"dport", m.DestinationPort.ValueString(), rule.Dport, &req.Dport},
+		{"icmp-type", m.ICMPType.ValueString(), rule.ICMPType, &req.ICMPType},
+		{"iface", m.Interface.ValueString(), rule.Iface, &req.Iface},
+		{"log", m.LogLevel.ValueString(), rule.Log, &req.Log},
+		{"macro", m.Macro.ValueString(), rule.Macro, &req.Macro},
+		{"proto", m.Proto.ValueString(), rule.Proto, &req.Proto},
+		{"source", m.Source.ValueString(), rule.Source, &req.Source},
+		{"sport", m.Sport.ValueString(), rule.Sport, &req.Sport},
+	}
+	for _, param := range params {
+		if param.planned != "" {
+			*param.field = &param.planned
+		} else if param.current != "" {
+			req.Delete = append(req.Delete, param.name)
+		}
+	}
+
+	if err := s.c.UpdateNodeFirewallRule(ctx, req); err != nil {
+		return err
+	}
+	tflog.Info(ctx, "updated node firewall rule", map[string]any{"node": s.node, "pos": rule.Pos, "id": id})
+
+	return s.refreshDigest(ctx)
+}
+
+// adoptRules assigns a go-proxmox id to every node rule
+// that lacks the comment prefix and returns the ids of all
+// the node rules ordered by position.
+func adoptRules(ctx context.Context, c *proxmox.Client, node string) ([]string, error) {
+	ids := []string{}
+	err := lockRules(ctx, c, node, func() error {
+		remote, err := getRemoteRules(ctx, c, node)
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range remote.rules {
+			if id, _, ok := splitRuleComment(rule.Comment); ok {
+				ids = append(ids, id)
+				continue
+			}
+
+			id, err := remote.adopt(ctx, rule)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+
+	return ids, err
+}
+
+// delete deletes the given rules starting with the highest
+// position, so the positions of the remaining ones stay valid.
+func (s *remoteRules) delete(ctx context.Context, rules []proxmox.NodeFirewallRule) error {
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Pos > rules[j].Pos })
+
+	for _, rule := range rules {
+		if err := s.c.DeleteNodeFirewallRule(ctx, s.node, rule.Pos, s.digest); err != nil {
+			return err
+		}
+		if err := s.refreshDigest(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// create creates the rule through go-proxmox and returns its id.
+// The rule is inserted at the first position, the other rules
+// must not be changed through the snapshot afterwards.
+func (s *remoteRules) create(ctx context.Context, rule ruleModel) (string, error) {
+	enable := 0
+	if rule.Enable.ValueBool() {
+		enable = 1
+	}
+
+	req := pve.CreateNodeFirewallRuleRequest{
+		Action:          rule.Action.ValueString(),
+		Node:            s.node,
+		Type:            rule.Type.ValueString(),
+		Comment:         rule.Comment.ValueString(),
+		Destination:     rule.Destination.ValueString(),
+		Digest:          s.digest,
+		DestinationPort: rule.DestinationPort.ValueString(),
+		Enable:          enable,
+		ICMPType:        rule.ICMPType.ValueString(),
+		Interface:       rule.Interface.ValueString(),
+		LogLevel:        pve.FirewallLogLevel(rule.LogLevel.ValueString()),
+		Macro:           rule.Macro.ValueString(),
+		// FIXME: POS NOT WORKING
+		//Pos:             int(rule.Pos.ValueInt64()),
+		Proto:  rule.Proto.ValueString(),
+		Source: rule.Source.ValueString(),
+		Sport:  rule.Sport.ValueString(),
+	}
+
+	var id string
+	err := s.c.RetryLocked(ctx, func() (err error) {
+		id, err = s.c.Node.Firewall.NewRule(req)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, s.refreshDigest(ctx)
+}
@@ -0,0 +1,151 @@
+package nodefirewall
+
+import (
+	"terraform-provider-proxmox/internal/proxmox"
+	"testing"
+)
+
+func TestSplitRuleComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		id      string
+		text    string
+		ok      bool
+	}{
+		{"[abc] allow ssh", "abc", "allow ssh", true},
+		{"[abc]allow ssh", "abc", "allow ssh", true},
+		{"[abc]", "abc", "", true},
+		{"[abc]  two spaces", "abc", " two spaces", true},
+		{"[] empty id", "", "[] empty id", false},
+		{"[a", "", "[a", false},
+		{"allow ssh", "", "allow ssh", false},
+		{"allow [abc] ssh", "", "allow [abc] ssh", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			id, text, ok := splitRuleComment(tt.comment)
+			if id != tt.id || text != tt.text || ok != tt.ok {
+				t.Errorf("splitRuleComment(%q) = %q, %q, %v, want %q, %q, %v", tt.comment, id, text, ok, tt.id, tt.text, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRuleFingerprint(t *testing.T) {
+	base := func() []string {
+		return []string{"in", "ACCEPT", "", "vmbr0", "10.0.0.1", "", "tcp", "", "22", "", "info"}
+	}
+	fingerprint := func(fields []string, enable bool, comment string) string {
+		return ruleFingerprint(fields[0], fields[1], fields[2], fields[3], fields[4], fields[5], fields[6], fields[7], fields[8], fields[9], fields[10], enable, comment)
+	}
+
+	nolog := base()
+	nolog[10] = "nolog"
+	nologEmpty := base()
+	nologEmpty[10] = ""
+	otherPort := base()
+	otherPort[8] = "23"
+	// fields are separated, so values cannot leak into the next ones.
+	shifted := base()
+	shifted[3], shifted[4] = "vmbr010.0.0.1", ""
+
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"same rule", fingerprint(base(), true, "ssh"), fingerprint(base(), true, "ssh"), true},
+		{"nolog is no log", fingerprint(nolog, true, "ssh"), fingerprint(nologEmpty, true, "ssh"), true},
+		{"other port", fingerprint(base(), true, "ssh"), fingerprint(otherPort, true, "ssh"), false},
+		{"other enable", fingerprint(base(), true, "ssh"), fingerprint(base(), false, "ssh"), false},
+		{"other comment", fingerprint(base(), true, "ssh"), fingerprint(base(), true, "ssh2"), false},
+		{"shifted fields", fingerprint(base(), true, "ssh"), fingerprint(shifted, true, "ssh"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.a == tt.b) != tt.equal {
+				t.Errorf("fingerprints equal = %v, want %v", tt.a == tt.b, tt.equal)
+			}
+		})
+	}
+}
+
+func TestRemoteRulesMatch(t *testing.T) {
+	rule := func(pos int, comment, dport string) proxmox.NodeFirewallRule {
+		return proxmox.NodeFirewallRule{Pos: pos, Type: "in", Action: "ACCEPT", Proto: "tcp", Dport: dport, Enable: 1, Comment: comment}
+	}
+	ssh := remoteRuleFingerprint(rule(0, "ssh", "22"))
+
+	tests := []struct {
+		name    string
+		rules   []proxmox.NodeFirewallRule
+		claimed map[int]bool
+		pos     int
+		ok      bool
+	}{
+		{
+			name:  "rule without id",
+			rules: []proxmox.NodeFirewallRule{rule(0, "http", "80"), rule(1, "ssh", "22")},
+			pos:   1,
+			ok:    true,
+		},
+		{
+			name:  "first matching rule",
+			rules: []proxmox.NodeFirewallRule{rule(0, "ssh", "22"), rule(1, "ssh", "22")},
+			pos:   0,
+			ok:    true,
+		},
+		{
+			name:    "claimed rule skipped",
+			rules:   []proxmox.NodeFirewallRule{rule(0, "ssh", "22"), rule(1, "ssh", "22")},
+			claimed: map[int]bool{0: true},
+			pos:     1,
+			ok:      true,
+		},
+		{
+			name:  "rule with id skipped",
+			rules: []proxmox.NodeFirewallRule{rule(0, "[abc] ssh", "22")},
+			ok:    false,
+		},
+		{
+			name:  "other content",
+			rules: []proxmox.NodeFirewallRule{rule(0, "ssh", "2222")},
+			ok:    false,
+		},
+		{
+			name: "no rules",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed := map[int]bool{}
+			for i := range tt.claimed {
+				claimed[i] = true
+			}
+			s := &remoteRules{rules: tt.rules, claimed: claimed}
+
+			got, ok := s.match(ssh)
+			if ok != tt.ok {
+				t.Fatalf("match ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.Pos != tt.pos {
+				t.Errorf("match pos = %d, want %d", got.Pos, tt.pos)
+			}
+			if !s.claimed[tt.pos] {
+				t.Errorf("matched rule %d is not claimed", tt.pos)
+			}
+			if again, ok := s.match(ssh); ok && again.Pos == tt.pos {
+				t.Errorf("matched rule %d is matched again", tt.pos)
+			}
+		})
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,14 +19,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RuleResource{}
 var _ resource.ResourceWithImportState = &RuleResource{}
+var _ resource.ResourceWithModifyPlan = &RuleResource{}
 
 func NewRuleResource() resource.Resource {
 	return &RuleResource{}
@@ -30,11 +35,12 @@ func NewRuleResource() resource.Resource {
 
 // RuleResource defines the resource implementation.
 type RuleResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // RuleResourceModel describes the resource data model.
 type RuleResourceModel struct {
+	Cluster types.String `tfsdk:"cluster"`
 	ruleModel
 	Node types.String `tfsdk:"node"`
 }
@@ -49,6 +55,7 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		MarkdownDescription: "Node firewall rules resource",
 		Description:         DESC_RULE,
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
 			"node": schema.StringAttribute{
 				Description: DESC_RULE_NODE,
 				Required:    true,
@@ -66,6 +73,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"action": schema.StringAttribute{
 				Required:    true,
 				Description: DESC_RULE_ACTION,
+				Validators: []validator.String{
+					validators.FirewallAction(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -84,6 +94,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_DEST,
+				Validators: []validator.String{
+					validators.FirewallAddress(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -93,6 +106,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_DPORT,
+				Validators: []validator.String{
+					validators.FirewallPorts(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -136,6 +152,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_LOG,
+				Validators: []validator.String{
+					validators.FirewallLogLevel(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -162,6 +181,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_PROTO,
+				Validators: []validator.String{
+					validators.FirewallProto(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -171,6 +193,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_SOURCE,
+				Validators: []validator.String{
+					validators.FirewallAddress(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -180,6 +205,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_SPORT,
+				Validators: []validator.String{
+					validators.FirewallPorts(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -187,6 +215,9 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"type": schema.StringAttribute{
 				Required:    true,
 				Description: DESC_RULE_TYPE,
+				Validators: []validator.String{
+					validators.FirewallType(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -202,11 +233,11 @@ func (r *RuleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -215,6 +246,21 @@ func (r *RuleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *RuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	if node, ok := preflight.PlannedString(ctx, resp, path.Root("node")); ok {
+		preflight.Require(client, &resp.Diagnostics, "Sys.Modify", "/nodes/"+node)
+	}
+}
+
 func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RuleResourceModel
 
@@ -225,34 +271,18 @@ func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	enable := 0
-	if data.Enable.ValueBool() {
-		enable = 1
-	}
-
-	apiReq := pve.CreateNodeFirewallRuleRequest{
-		Action:      data.Action.ValueString(),
-		Node:        data.Node.ValueString(),
-		Type:        data.Type.ValueString(),
-		Comment:     data.Comment.ValueString(),
-		Destination: data.Destination.ValueString(),
-		//Digest:          data.Digest.ValueString(),
-		DestinationPort: data.DestinationPort.ValueString(),
-		Enable:          enable,
-		ICMPType:        data.ICMPType.ValueString(),
-		Interface:       data.Interface.ValueString(),
-		LogLevel:        pve.FirewallLogLevel(data.LogLevel.ValueString()),
-		Macro:           data.Macro.ValueString(),
-		// FIXME: POS NOT WORKING
-		//Pos:             int(data.Pos.ValueInt64()),
-		Proto:  data.Proto.ValueString(),
-		Source: data.Source.ValueString(),
-		Sport:  data.Sport.ValueString(),
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	id, err := r.client.Node.Firewall.NewRule(apiReq)
+	// If applicable, this is a great opportunity to initialize any necessary
+	// provider client data and make a call using it.
+	var id string
+	err := lockRules(ctx, client, data.Node.ValueString(), func() (err error) {
+		id, err = r.create(ctx, client, data)
+		return err
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rule, got error: %s", err))
 		return
@@ -277,47 +307,26 @@ func (r *RuleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	tflog.Debug(ctx, "GetRule args", map[string]any{
-		"id":   data.ID.ValueString(),
-		"node": data.Node.ValueString(),
-	})
-	remoteRule, err := r.client.Node.Firewall.GetRule(
-		data.Node.ValueString(),
-		data.ID.ValueString(),
-	)
+	remote, err := getRemoteRules(ctx, client, data.Node.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node firewall rule, got error: %s", err))
 		return
 	}
-	data.ID = types.StringValue(remoteRule.ID)
-	data.Action = types.StringValue(remoteRule.Action)
-	idx := strings.IndexRune(remoteRule.Comment, ']')
-	comment := ""
-	if len(remoteRule.Comment) >= idx+2 {
-		comment = remoteRule.Comment[idx+2:]
-	}
-	data.Comment = types.StringValue(comment)
 
-	data.Destination = types.StringValue(remoteRule.Destination)
-	data.DestinationPort = types.StringValue(remoteRule.DestinationPort)
-	enable := false
-	if remoteRule.Enable == 1 {
-		enable = true
+	remoteRule, ok := remote.claim(data.ID.ValueString(), data.fingerprint())
+	if !ok {
+		tflog.Warn(ctx, "node firewall rule not found, removing it from state", map[string]any{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
 	}
-	data.Enable = types.BoolValue(enable)
-	data.ICMPType = types.StringValue(remoteRule.ICMPType)
-	data.Interface = types.StringValue(remoteRule.Interface)
-	data.IPVersion = types.Int64Value(int64(remoteRule.IPVersion))
-	data.LogLevel = types.StringValue(string(remoteRule.LogLevel))
-	data.Macro = types.StringValue(remoteRule.Macro)
-	// FIXME: POS NOT WORKING
-	//data.Pos = types.Int64Value(int64(remoteRule.Pos))
-	data.Proto = types.StringValue(remoteRule.Proto)
-	data.Source = types.StringValue(remoteRule.Source)
-	data.Sport = types.StringValue(remoteRule.Sport)
-	data.Type = types.StringValue(remoteRule.Type)
+	data.loadRemote(remoteRule)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -335,41 +344,20 @@ func (r *RuleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	if err := r.client.Node.Firewall.DeleteRule(data.Node.ValueString(), data.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	enable := 0
-	if data.Enable.ValueBool() {
-		enable = 1
-	}
-
-	apiReq := pve.CreateNodeFirewallRuleRequest{
-		Action:      data.Action.ValueString(),
-		Node:        data.Node.ValueString(),
-		Type:        data.Type.ValueString(),
-		Comment:     data.Comment.ValueString(),
-		Destination: data.Destination.ValueString(),
-		//Digest:          data.Digest.ValueString(),
-		DestinationPort: data.DestinationPort.ValueString(),
-		Enable:          enable,
-		ICMPType:        data.ICMPType.ValueString(),
-		Interface:       data.Interface.ValueString(),
-		LogLevel:        pve.FirewallLogLevel(data.LogLevel.ValueString()),
-		Macro:           data.Macro.ValueString(),
-		// FIXME: POS NOT WORKING
-		// Pos:             int(data.Pos.ValueInt64()),
-		Proto:  data.Proto.ValueString(),
-		Source: data.Source.ValueString(),
-		Sport:  data.Sport.ValueString(),
-	}
-
-	id, err := r.client.Node.Firewall.NewRule(apiReq)
+	// If applicable, this is a great opportunity to initialize any necessary
+	// provider client data and make a call using it.
+	var id string
+	err := lockRules(ctx, client, data.Node.ValueString(), func() (err error) {
+		id, err = r.update(ctx, client, data, state)
+		return err
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rule, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update node firewall rule, got error: %s", err))
 		return
 	}
 	data.ID = types.StringValue(id)
@@ -388,11 +376,19 @@ func (r *RuleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	if err := r.client.Node.Firewall.DeleteRule(data.Node.ValueString(), data.ID.ValueString()); err != nil {
+	err := lockRules(ctx, client, data.Node.ValueString(), func() error {
+		return r.delete(ctx, client, data)
+	})
+	if err != nil {
 		tflog.Error(ctx, err.Error())
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete node firewall rule, got error: %s", err))
 		return
 	}
 
@@ -401,6 +397,77 @@ func (r *RuleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	tflog.Trace(ctx, "deleted a resource")
 }
 
+// create adopts the node rule without id that matches the
+// planned rule content, if any, otherwise the rule is created.
+// It must be called holding the node rules lock.
+func (r *RuleResource) create(ctx context.Context, client *proxmox.Client, data RuleResourceModel) (string, error) {
+	node := data.Node.ValueString()
+	remote, err := getRemoteRules(ctx, client, node)
+	if err != nil {
+		return "", err
+	}
+
+	if remoteRule, ok := remote.match(data.fingerprint()); ok {
+		return remote.adopt(ctx, remoteRule)
+	}
+
+	return remote.create(ctx, data.ruleModel)
+}
+
+// update changes the rule in place, it is created again when it
+// no longer exists. It must be called holding the node rules lock.
+func (r *RuleResource) update(ctx context.Context, client *proxmox.Client, data RuleResourceModel, state RuleResourceModel) (string, error) {
+	remote, err := getRemoteRules(ctx, client, data.Node.ValueString())
+	if err != nil {
+		return "", err
+	}
+
+	remoteRule, ok := remote.claim(state.ID.ValueString(), state.fingerprint())
+	if !ok {
+		return r.create(ctx, client, data)
+	}
+
+	id, _, ok := splitRuleComment(remoteRule.Comment)
+	if !ok {
+		if id, err = newRuleID(); err != nil {
+			return "", err
+		}
+	}
+
+	return id, remote.update(ctx, remoteRule, id, data.ruleModel)
+}
+
+// delete deletes the rule from the node, rules that no longer
+// exist are ignored. It must be called holding the node rules lock.
+func (r *RuleResource) delete(ctx context.Context, client *proxmox.Client, data RuleResourceModel) error {
+	node := data.Node.ValueString()
+	remote, err := getRemoteRules(ctx, client, node)
+	if err != nil {
+		return err
+	}
+
+	remoteRule, ok := remote.claim(data.ID.ValueString(), data.fingerprint())
+	if !ok {
+		return nil
+	}
+
+	return remote.delete(ctx, []proxmox.NodeFirewallRule{remoteRule})
+}
+
+// ImportState imports a rule using the "node/id" format, where
+// id is the go-proxmox id that lives within the rule comment.
 func (r *RuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	cluster, importID := clusterattr.ImportID(req.ID)
+	node, id, found := strings.Cut(importID, "/")
+	if !found || node == "" || id == "" || strings.Contains(id, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: [cluster:]node/id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(clusterattr.Name), cluster)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node"), node)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
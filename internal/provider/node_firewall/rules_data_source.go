@@ -3,12 +3,14 @@ package nodefirewall
 import (
 	"context"
 	"fmt"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -24,7 +26,7 @@ func NewRulesDataSource() datasource.DataSource {
 
 // coffeesDataSource is the data source implementation.
 type rulesDataSource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // Metadata returns the data source type name.
@@ -57,6 +59,7 @@ func (d *rulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
 			"node": schema.StringAttribute{
 				Required: true,
 			},
@@ -72,10 +75,15 @@ func (d *rulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 func (d *rulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state rulesDataSourceModel
 	resp.State.Get(ctx, &state)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(clusterattr.Name), &state.Cluster)...)
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	state.Rules = []ruleModel{}
 	tflog.Info(ctx, "reading node firewall rules", map[string]interface{}{"node": state.Node.ValueString()})
 
-	rules, err := d.client.Node.Firewall.GetRules(state.Node.ValueString())
+	rules, err := client.GetNodeFirewallRules(ctx, state.Node.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Proxmox Rules",
@@ -87,11 +95,15 @@ func (d *rulesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	for _, r := range rules {
 		rule := ruleModel{}
 
-		rule.ID = types.StringValue(r.ID)
+		// rules created outside terraform have no id.
+		rule.ID = types.StringNull()
+		if id, _, ok := splitRuleComment(r.Comment); ok {
+			rule.ID = types.StringValue(id)
+		}
 		rule.Action = types.StringValue(r.Action)
 		rule.Comment = types.StringValue(r.Comment)
-		rule.Destination = types.StringValue(r.Destination)
-		rule.DestinationPort = types.StringValue(r.DestinationPort)
+		rule.Destination = types.StringValue(r.Dest)
+		rule.DestinationPort = types.StringValue(r.Dport)
 		switch r.Enable {
 		case 0:
 			rule.Enable = types.BoolValue(false)
@@ -107,9 +119,9 @@ func (d *rulesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			return
 		}
 		rule.ICMPType = types.StringValue(r.ICMPType)
-		rule.Interface = types.StringValue(r.Interface)
+		rule.Interface = types.StringValue(r.Iface)
 		rule.IPVersion = types.Int64Value(int64(r.IPVersion))
-		rule.LogLevel = types.StringValue(string(r.LogLevel))
+		rule.LogLevel = types.StringValue(r.Log)
 		rule.Macro = types.StringValue(r.Macro)
 		rule.Pos = types.Int64Value(int64(r.Pos))
 		rule.Proto = types.StringValue(r.Proto)
@@ -136,11 +148,11 @@ func (d *rulesDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -151,8 +163,9 @@ func (d *rulesDataSource) Configure(_ context.Context, req datasource.ConfigureR
 
 // rulesDataSourceModel maps the data source schema data.
 type rulesDataSourceModel struct {
-	Node  types.String `tfsdk:"node"`
-	Rules []ruleModel  `tfsdk:"rules"`
+	Cluster types.String `tfsdk:"cluster"`
+	Node    types.String `tfsdk:"node"`
+	Rules   []ruleModel  `tfsdk:"rules"`
 }
 
 // rulesModel maps rule schema data.
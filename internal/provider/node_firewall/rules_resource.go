@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,14 +15,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RulesResource{}
 var _ resource.ResourceWithImportState = &RulesResource{}
+var _ resource.ResourceWithModifyPlan = &RulesResource{}
 
 func NewRulesResource() resource.Resource {
 	return &RulesResource{}
@@ -26,13 +31,15 @@ func NewRulesResource() resource.Resource {
 
 // RulesResource defines the resource implementation.
 type RulesResource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // RulesResourceModel describes the resource data model.
 type RulesResourceModel struct {
-	Node  types.String `tfsdk:"node"`
-	Rules []ruleModel  `tfsdk:"rules"`
+	Cluster   types.String `tfsdk:"cluster"`
+	Node      types.String `tfsdk:"node"`
+	Exclusive types.String `tfsdk:"exclusive"`
+	Rules     []ruleModel  `tfsdk:"rules"`
 }
 
 func (r *RulesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +58,9 @@ func (r *RulesResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"action": schema.StringAttribute{
 				Required:    true,
 				Description: DESC_RULE_ACTION,
+				Validators: []validator.String{
+					validators.FirewallAction(),
+				},
 			},
 			"comment": schema.StringAttribute{
 				Computed:    true,
@@ -63,12 +73,18 @@ func (r *RulesResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_DEST,
+				Validators: []validator.String{
+					validators.FirewallAddress(),
+				},
 			},
 			"dport": schema.StringAttribute{
 				Computed:    true,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_DPORT,
+				Validators: []validator.String{
+					validators.FirewallPorts(),
+				},
 			},
 			"enable": schema.BoolAttribute{
 				Computed:    true,
@@ -98,6 +114,9 @@ func (r *RulesResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_LOG,
+				Validators: []validator.String{
+					validators.FirewallLogLevel(),
+				},
 			},
 			"macro": schema.StringAttribute{
 				Computed:    true,
@@ -115,22 +134,34 @@ func (r *RulesResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_PROTO,
+				Validators: []validator.String{
+					validators.FirewallProto(),
+				},
 			},
 			"source": schema.StringAttribute{
 				Computed:    true,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_SOURCE,
+				Validators: []validator.String{
+					validators.FirewallAddress(),
+				},
 			},
 			"sport": schema.StringAttribute{
 				Computed:    true,
 				Optional:    true,
 				Default:     stringdefault.StaticString(""),
 				Description: DESC_RULE_SPORT,
+				Validators: []validator.String{
+					validators.FirewallPorts(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:    true,
 				Description: DESC_RULE_TYPE,
+				Validators: []validator.String{
+					validators.FirewallType(),
+				},
 			},
 		},
 	}
@@ -138,10 +169,20 @@ func (r *RulesResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Node firewall rules resource",
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
 			"node": schema.StringAttribute{
 				Required:    true,
 				Description: DESC_RULE_NODE,
 			},
+			"exclusive": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString(EXCLUSIVE_OFF),
+				Description: DESC_RULES_EXCLUSIVE,
+				Validators: []validator.String{
+					validators.OneOf(EXCLUSIVE_OFF, EXCLUSIVE_REPORT, EXCLUSIVE_REMOVE),
+				},
+			},
 			"rules": schema.ListNestedAttribute{
 				NestedObject: ruleSchema,
 				Required:     true,
@@ -158,11 +199,11 @@ func (r *RulesResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *pve.PVE, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -171,6 +212,21 @@ func (r *RulesResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *RulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	if node, ok := preflight.PlannedString(ctx, resp, path.Root("node")); ok {
+		preflight.Require(client, &resp.Diagnostics, "Sys.Modify", "/nodes/"+node)
+	}
+}
+
 func (r *RulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RulesResourceModel
 
@@ -181,40 +237,16 @@ func (r *RulesResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	for i, rule := range data.Rules {
-		enable := 0
-		if rule.Enable.ValueBool() {
-			enable = 1
-		}
-
-		apiReq := pve.CreateNodeFirewallRuleRequest{
-			Action:      rule.Action.ValueString(),
-			Node:        data.Node.ValueString(),
-			Type:        rule.Type.ValueString(),
-			Comment:     rule.Comment.ValueString(),
-			Destination: rule.Destination.ValueString(),
-			//Digest:          rule.Digest.ValueString(),
-			DestinationPort: rule.DestinationPort.ValueString(),
-			Enable:          enable,
-			ICMPType:        rule.ICMPType.ValueString(),
-			Interface:       rule.Interface.ValueString(),
-			LogLevel:        pve.FirewallLogLevel(rule.LogLevel.ValueString()),
-			Macro:           rule.Macro.ValueString(),
-			// FIXME: POS NOT WORKING
-			//Pos:             int(rule.Pos.ValueInt64()),
-			Proto:  rule.Proto.ValueString(),
-			Source: rule.Source.ValueString(),
-			Sport:  rule.Sport.ValueString(),
-		}
-
-		id, err := r.client.Node.Firewall.NewRule(apiReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
-			return
-		}
-		data.Rules[i].ID = types.StringValue(id)
+	if err := r.apply(ctx, client, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
+		return
 	}
 
 	// Write logs using the tflog package
@@ -235,42 +267,50 @@ func (r *RulesResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	for i, rule := range data.Rules {
-		remoteRule, err := r.client.Node.Firewall.GetRule(
-			data.Node.ValueString(),
-			rule.ID.ValueString(),
-		)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
-			return
+	remote, err := getRemoteRules(ctx, client, data.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node firewall rules, got error: %s", err))
+		return
+	}
+
+	rules := []ruleModel{}
+	for _, rule := range data.Rules {
+		remoteRule, ok := remote.claim(rule.ID.ValueString(), rule.fingerprint())
+		if !ok {
+			tflog.Warn(ctx, "node firewall rule not found, removing it from state", map[string]any{"id": rule.ID.ValueString()})
+			continue
 		}
-		data.Rules[i].ID = types.StringValue(remoteRule.ID)
-		data.Rules[i].Action = types.StringValue(remoteRule.Action)
-		idx := strings.IndexRune(remoteRule.Comment, ']')
-		comment := remoteRule.Comment[idx+2:]
-		data.Rules[i].Comment = types.StringValue(comment)
-
-		data.Rules[i].Destination = types.StringValue(remoteRule.Destination)
-		data.Rules[i].DestinationPort = types.StringValue(remoteRule.DestinationPort)
-		enable := false
-		if remoteRule.Enable == 1 {
-			enable = true
+		rule.loadRemote(remoteRule)
+		rules = append(rules, rule)
+	}
+
+	for _, remoteRule := range remote.unclaimed() {
+		switch data.Exclusive.ValueString() {
+		case EXCLUSIVE_REPORT:
+			resp.Diagnostics.AddWarning(
+				"Unmanaged Node Firewall Rule",
+				fmt.Sprintf("Node %s has a firewall rule at position %d that is not managed by this resource: %s %s (%q).",
+					data.Node.ValueString(), remoteRule.Pos, remoteRule.Type, remoteRule.Action, remoteRule.Comment),
+			)
+		case EXCLUSIVE_REMOVE:
+			// unmanaged rules are added to the state so they
+			// show up as rules to be removed in the plan.
+			rule := ruleModel{ID: types.StringNull(), Pos: types.Int64Null()}
+			if id, _, ok := splitRuleComment(remoteRule.Comment); ok {
+				rule.ID = types.StringValue(id)
+			}
+			rule.loadRemote(remoteRule)
+			rules = append(rules, rule)
 		}
-		data.Rules[i].Enable = types.BoolValue(enable)
-		data.Rules[i].ICMPType = types.StringValue(remoteRule.ICMPType)
-		data.Rules[i].Interface = types.StringValue(remoteRule.Interface)
-		data.Rules[i].IPVersion = types.Int64Value(int64(remoteRule.IPVersion))
-		data.Rules[i].LogLevel = types.StringValue(string(remoteRule.LogLevel))
-		data.Rules[i].Macro = types.StringValue(remoteRule.Macro)
-		// FIXME: POS NOT WORKING
-		//data.Rules[i].Pos = types.Int64Value(int64(remoteRule.Pos))
-		data.Rules[i].Proto = types.StringValue(remoteRule.Proto)
-		data.Rules[i].Source = types.StringValue(remoteRule.Source)
-		data.Rules[i].Sport = types.StringValue(remoteRule.Sport)
-		data.Rules[i].Type = types.StringValue(remoteRule.Type)
 	}
+	data.Rules = rules
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -288,47 +328,16 @@ func (r *RulesResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	for _, rule := range state.Rules {
-		if err := r.client.Node.Firewall.DeleteRule(data.Node.ValueString(), rule.ID.ValueString()); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
-			return
-		}
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for i, rule := range data.Rules {
-		enable := 0
-		if rule.Enable.ValueBool() {
-			enable = 1
-		}
-
-		apiReq := pve.CreateNodeFirewallRuleRequest{
-			Action:      rule.Action.ValueString(),
-			Node:        data.Node.ValueString(),
-			Type:        rule.Type.ValueString(),
-			Comment:     rule.Comment.ValueString(),
-			Destination: rule.Destination.ValueString(),
-			//Digest:          rule.Digest.ValueString(),
-			DestinationPort: rule.DestinationPort.ValueString(),
-			Enable:          enable,
-			ICMPType:        rule.ICMPType.ValueString(),
-			Interface:       rule.Interface.ValueString(),
-			LogLevel:        pve.FirewallLogLevel(rule.LogLevel.ValueString()),
-			Macro:           rule.Macro.ValueString(),
-			// FIXME: POS NOT WORKING
-			// Pos:             int(rule.Pos.ValueInt64()),
-			Proto:  rule.Proto.ValueString(),
-			Source: rule.Source.ValueString(),
-			Sport:  rule.Sport.ValueString(),
-		}
-
-		id, err := r.client.Node.Firewall.NewRule(apiReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
-			return
-		}
-		data.Rules[i].ID = types.StringValue(id)
+	// If applicable, this is a great opportunity to initialize any necessary
+	// provider client data and make a call using it.
+	if err := r.apply(ctx, client, &data, &state); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update node firewall rules, got error: %s", err))
+		return
 	}
 
 	// Save updated data into Terraform state
@@ -345,14 +354,32 @@ func (r *RulesResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	for _, rule := range data.Rules {
-		if err := r.client.Node.Firewall.DeleteRule(data.Node.ValueString(), rule.ID.ValueString()); err != nil {
-			tflog.Error(ctx, err.Error())
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node firewall rules, got error: %s", err))
-			return
+	node := data.Node.ValueString()
+	err := lockRules(ctx, client, node, func() error {
+		remote, err := getRemoteRules(ctx, client, node)
+		if err != nil {
+			return err
+		}
+
+		deletes := []proxmox.NodeFirewallRule{}
+		for _, rule := range data.Rules {
+			if remoteRule, ok := remote.claim(rule.ID.ValueString(), rule.fingerprint()); ok {
+				deletes = append(deletes, remoteRule)
+			}
 		}
+		return remote.delete(ctx, deletes)
+	})
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete node firewall rules, got error: %s", err))
+		return
 	}
 
 	// Write logs using the tflog package
@@ -360,6 +387,136 @@ func (r *RulesResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Trace(ctx, "deleted a resource")
 }
 
+// apply changes the rules in state (if any) into the planned
+// ones. The planned rules are compared with the state rules at
+// the same index, the changed ones are updated in place and only
+// the rules added or removed from the list are created or
+// deleted. Planned rules matching the content of a rule without
+// id are adopted instead of being created, and with the remove
+// exclusive mode, unmanaged rules are deleted.
+func (r *RulesResource) apply(ctx context.Context, client *proxmox.Client, data *RulesResourceModel, state *RulesResourceModel) error {
+	node := data.Node.ValueString()
+	return lockRules(ctx, client, node, func() error {
+		remote, err := getRemoteRules(ctx, client, node)
+		if err != nil {
+			return err
+		}
+
+		current := map[int]proxmox.NodeFirewallRule{}
+		deletes := []proxmox.NodeFirewallRule{}
+		if state != nil {
+			for i, rule := range state.Rules {
+				remoteRule, ok := remote.claim(rule.ID.ValueString(), rule.fingerprint())
+				if !ok {
+					continue
+				}
+				if i < len(data.Rules) {
+					current[i] = remoteRule
+				} else {
+					deletes = append(deletes, remoteRule)
+				}
+			}
+		}
+
+		adopted := map[int]proxmox.NodeFirewallRule{}
+		for i, rule := range data.Rules {
+			if _, ok := current[i]; ok {
+				continue
+			}
+			if remoteRule, ok := remote.match(rule.fingerprint()); ok {
+				adopted[i] = remoteRule
+			}
+		}
+
+		if data.Exclusive.ValueString() == EXCLUSIVE_REMOVE {
+			deletes = append(deletes, remote.unclaimed()...)
+		}
+
+		// updates and adoptions do not move the rules, so they must
+		// happen before any rule is deleted or created to keep
+		// positions valid.
+		for i, remoteRule := range current {
+			rule := data.Rules[i]
+			id, _, ok := splitRuleComment(remoteRule.Comment)
+			if !ok {
+				if id, err = newRuleID(); err != nil {
+					return err
+				}
+			}
+			data.Rules[i].ID = types.StringValue(id)
+
+			if ok && remoteRuleFingerprint(remoteRule) == rule.fingerprint() {
+				continue
+			}
+			if err := remote.update(ctx, remoteRule, id, rule); err != nil {
+				return err
+			}
+		}
+
+		for i, remoteRule := range adopted {
+			id, err := remote.adopt(ctx, remoteRule)
+			if err != nil {
+				return err
+			}
+			data.Rules[i].ID = types.StringValue(id)
+		}
+
+		if err := remote.delete(ctx, deletes); err != nil {
+			return err
+		}
+
+		for i, rule := range data.Rules {
+			if _, ok := current[i]; ok {
+				continue
+			}
+			if _, ok := adopted[i]; ok {
+				continue
+			}
+
+			id, err := remote.create(ctx, rule)
+			if err != nil {
+				return err
+			}
+			data.Rules[i].ID = types.StringValue(id)
+		}
+
+		return nil
+	})
+}
+
+// ImportState imports every rule of the node given as import
+// identifier. Rules without a go-proxmox id in their comment
+// get one assigned.
 func (r *RulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	cluster, node := clusterattr.ImportID(req.ID)
+	if node == "" || strings.Contains(node, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: [cluster:]node. Got: %q", req.ID),
+		)
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := adoptRules(ctx, client, node)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import node firewall rules, got error: %s", err))
+		return
+	}
+
+	state := RulesResourceModel{
+		Cluster:   cluster,
+		Node:      types.StringValue(node),
+		Exclusive: types.StringValue(EXCLUSIVE_OFF),
+		Rules:     []ruleModel{},
+	}
+	for _, id := range ids {
+		state.Rules = append(state.Rules, ruleModel{ID: types.StringValue(id)})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
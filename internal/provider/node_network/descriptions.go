@@ -0,0 +1,47 @@
+package nodenetwork
+
+// descriptions for network
+const (
+	DESC_NETWORK = "Node network interface (bridge, bond, vlan or " +
+		"open vswitch object).\n" +
+		"Changes are applied right away by reloading the node " +
+		"network config. The changes fail when the node already " +
+		"has pending network changes that were not made by " +
+		"terraform, they must be applied or reverted first."
+	DESC_NETWORK_ID    = "Network interface identifier: <node>/<iface>."
+	DESC_NETWORK_NODE  = "The cluster node name."
+	DESC_NETWORK_IFACE = "Network interface name, i.e. 'vmbr0', 'bond0' or 'vmbr0.100'."
+	DESC_NETWORK_TYPE  = "Network interface type.\n" +
+		"Values: bridge | bond | vlan | OVSBridge | OVSBond | OVSPort | OVSIntPort"
+	DESC_NETWORK_AUTOSTART = "Automatically start the interface on boot."
+	DESC_NETWORK_CIDR      = "IPv4 address with its network prefix length, i.e. '10.0.0.2/24'."
+	DESC_NETWORK_GATEWAY   = "Default IPv4 gateway address."
+	DESC_NETWORK_CIDR6     = "IPv6 address with its network prefix length."
+	DESC_NETWORK_GATEWAY6  = "Default IPv6 gateway address."
+	DESC_NETWORK_MTU       = "Maximum transmission unit (1280-65520)."
+	DESC_NETWORK_COMMENTS  = "Descriptive comment."
+	DESC_NETWORK_PORTS     = "Interfaces attached to the bridge (bridge, " +
+		"OVSBridge) or enslaved by the bond (bond, OVSBond)."
+	DESC_NETWORK_VLAN_AWARE = "Enable vlan support on the linux bridge."
+	DESC_NETWORK_VLAN_ID    = "VLAN tag (1-4094) of a vlan interface. " +
+		"Only required when it's not part of the interface name."
+	DESC_NETWORK_VLAN_RAW_DEVICE = "Parent interface of a vlan " +
+		"interface. Only required when it's not part of the " +
+		"interface name."
+	DESC_NETWORK_BOND_MODE = "Bonding mode.\n" +
+		"Values: balance-rr | active-backup | balance-xor | broadcast " +
+		"| 802.3ad | balance-tlb | balance-alb | balance-slb " +
+		"| lacp-balance-slb | lacp-balance-tcp"
+	DESC_NETWORK_BOND_PRIMARY     = "Primary interface of an active-backup bond."
+	DESC_NETWORK_BOND_XMIT_POLICY = "Bond transmit hash policy.\n" +
+		"Values: layer2 | layer2+3 | layer3+4"
+	DESC_NETWORK_OVS_BRIDGE  = "The OVSBridge the OVS object is added to."
+	DESC_NETWORK_OVS_TAG     = "VLAN tag (1-4094) of the OVS object."
+	DESC_NETWORK_OVS_OPTIONS = "OVS interface options."
+)
+
+// default values for network
+const (
+	DFLT_NETWORK_AUTOSTART  = true
+	DFLT_NETWORK_VLAN_AWARE = false
+)
@@ -0,0 +1,661 @@
+package nodenetwork
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NetworkResource{}
+var _ resource.ResourceWithImportState = &NetworkResource{}
+var _ resource.ResourceWithModifyPlan = &NetworkResource{}
+var _ resource.ResourceWithValidateConfig = &NetworkResource{}
+
+func NewNetworkResource() resource.Resource {
+	return &NetworkResource{}
+}
+
+// typeAttributes lists the interface types supported by the
+// type specific attributes.
+var typeAttributes = map[string][]string{
+	"ports":                 {"bridge", "bond", "OVSBridge", "OVSBond"},
+	"vlan_aware":            {"bridge"},
+	"vlan_id":               {"vlan"},
+	"vlan_raw_device":       {"vlan"},
+	"bond_mode":             {"bond", "OVSBond"},
+	"bond_primary":          {"bond"},
+	"bond_xmit_hash_policy": {"bond"},
+	"ovs_bridge":            {"OVSBond", "OVSPort", "OVSIntPort"},
+	"ovs_tag":               {"OVSBond", "OVSPort", "OVSIntPort"},
+	"ovs_options":           {"OVSBridge", "OVSBond", "OVSPort", "OVSIntPort"},
+}
+
+// NetworkResource defines the resource implementation.
+type NetworkResource struct {
+	client *proxmox.Client
+}
+
+// NetworkResourceModel describes the resource data model.
+type NetworkResourceModel struct {
+	Cluster            types.String `tfsdk:"cluster"`
+	ID                 types.String `tfsdk:"id"`
+	Node               types.String `tfsdk:"node"`
+	Iface              types.String `tfsdk:"iface"`
+	Type               types.String `tfsdk:"type"`
+	Autostart          types.Bool   `tfsdk:"autostart"`
+	CIDR               types.String `tfsdk:"cidr"`
+	Gateway            types.String `tfsdk:"gateway"`
+	CIDR6              types.String `tfsdk:"cidr6"`
+	Gateway6           types.String `tfsdk:"gateway6"`
+	MTU                types.Int64  `tfsdk:"mtu"`
+	Comments           types.String `tfsdk:"comments"`
+	Ports              types.List   `tfsdk:"ports"`
+	VlanAware          types.Bool   `tfsdk:"vlan_aware"`
+	VlanID             types.Int64  `tfsdk:"vlan_id"`
+	VlanRawDevice      types.String `tfsdk:"vlan_raw_device"`
+	BondMode           types.String `tfsdk:"bond_mode"`
+	BondPrimary        types.String `tfsdk:"bond_primary"`
+	BondXmitHashPolicy types.String `tfsdk:"bond_xmit_hash_policy"`
+	OVSBridge          types.String `tfsdk:"ovs_bridge"`
+	OVSTag             types.Int64  `tfsdk:"ovs_tag"`
+	OVSOptions         types.String `tfsdk:"ovs_options"`
+}
+
+func (r *NetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "node_network"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Node network interface resource",
+		Description:         DESC_NETWORK,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_NETWORK_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_NETWORK_NODE,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"iface": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_NETWORK_IFACE,
+				Validators: []validator.String{
+					validators.Regex(
+						regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\.\_\-]{1,14}$`),
+						"value must be a valid interface name (2-15 characters)",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_NETWORK_TYPE,
+				Validators: []validator.String{
+					validators.OneOf("bridge", "bond", "vlan", "OVSBridge", "OVSBond", "OVSPort", "OVSIntPort"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"autostart": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_NETWORK_AUTOSTART),
+				Description: DESC_NETWORK_AUTOSTART,
+			},
+			"cidr": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_CIDR,
+				Validators: []validator.String{
+					validators.CIDR(4),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_GATEWAY,
+				Validators: []validator.String{
+					validators.IPAddress(4),
+				},
+			},
+			"cidr6": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_CIDR6,
+				Validators: []validator.String{
+					validators.CIDR(6),
+				},
+			},
+			"gateway6": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_GATEWAY6,
+				Validators: []validator.String{
+					validators.IPAddress(6),
+				},
+			},
+			"mtu": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_NETWORK_MTU,
+				Validators: []validator.Int64{
+					validators.Between(1280, 65520),
+				},
+			},
+			"comments": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_COMMENTS,
+			},
+			"ports": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: DESC_NETWORK_PORTS,
+			},
+			"vlan_aware": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_NETWORK_VLAN_AWARE),
+				Description: DESC_NETWORK_VLAN_AWARE,
+			},
+			"vlan_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_NETWORK_VLAN_ID,
+				Validators: []validator.Int64{
+					validators.Between(1, 4094),
+				},
+			},
+			"vlan_raw_device": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_VLAN_RAW_DEVICE,
+			},
+			"bond_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_BOND_MODE,
+				Validators: []validator.String{
+					validators.OneOf(
+						"balance-rr", "active-backup", "balance-xor", "broadcast",
+						"802.3ad", "balance-tlb", "balance-alb", "balance-slb",
+						"lacp-balance-slb", "lacp-balance-tcp",
+					),
+				},
+			},
+			"bond_primary": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_BOND_PRIMARY,
+			},
+			"bond_xmit_hash_policy": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_BOND_XMIT_POLICY,
+				Validators: []validator.String{
+					validators.OneOf("layer2", "layer2+3", "layer3+4"),
+				},
+			},
+			"ovs_bridge": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_OVS_BRIDGE,
+			},
+			"ovs_tag": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_NETWORK_OVS_TAG,
+				Validators: []validator.Int64{
+					validators.Between(1, 4094),
+				},
+			},
+			"ovs_options": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_NETWORK_OVS_OPTIONS,
+			},
+		},
+	}
+}
+
+func (r *NetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig ensures the type specific attributes are only
+// used along with the types supporting them.
+func (r *NetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NetworkResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+	typ := data.Type.ValueString()
+
+	values := map[string]attr.Value{
+		"ports":                 data.Ports,
+		"vlan_aware":            data.VlanAware,
+		"vlan_id":               data.VlanID,
+		"vlan_raw_device":       data.VlanRawDevice,
+		"bond_mode":             data.BondMode,
+		"bond_primary":          data.BondPrimary,
+		"bond_xmit_hash_policy": data.BondXmitHashPolicy,
+		"ovs_bridge":            data.OVSBridge,
+		"ovs_tag":               data.OVSTag,
+		"ovs_options":           data.OVSOptions,
+	}
+	for name, supported := range typeAttributes {
+		if values[name].IsNull() || slices.Contains(supported, typ) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Unsupported Network Interface Attribute",
+			fmt.Sprintf("Attribute %s can only be used with the %s interface types, got: %q.",
+				name, strings.Join(supported, ", "), typ),
+		)
+	}
+
+	switch typ {
+	case "bond", "OVSBond":
+		if data.Ports.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ports"),
+				"Missing Network Interface Attribute",
+				fmt.Sprintf("Attribute ports is required by the %s interface type.", typ),
+			)
+		}
+	}
+
+	switch typ {
+	case "OVSBond", "OVSPort", "OVSIntPort":
+		if data.OVSBridge.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ovs_bridge"),
+				"Missing Network Interface Attribute",
+				fmt.Sprintf("Attribute ovs_bridge is required by the %s interface type.", typ),
+			)
+		}
+	}
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *NetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	if node, ok := preflight.PlannedString(ctx, resp, path.Root("node")); ok {
+		preflight.Require(client, &resp.Diagnostics, "Sys.Modify", "/nodes/"+node)
+	}
+}
+
+func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NetworkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := data.Node.ValueString()
+	err := r.commit(ctx, client, node, func() error {
+		return client.CreateNodeNetwork(ctx, data.toRequest())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create node network interface, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", node, data.Iface.ValueString()))
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node network interface, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NetworkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "node network interface not found, removing it from state", map[string]any{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node network interface, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NetworkResourceModel
+	var state NetworkResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest()
+	apiReq.Delete = data.deleted(state)
+
+	err := r.commit(ctx, client, data.Node.ValueString(), func() error {
+		return client.UpdateNodeNetwork(ctx, apiReq)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update node network interface, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node network interface, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NetworkResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := data.Node.ValueString()
+	err := r.commit(ctx, client, node, func() error {
+		err := client.DeleteNodeNetwork(ctx, node, data.Iface.ValueString())
+		if proxmox.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete node network interface, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// ImportState imports an interface using the "node/iface" format.
+func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	cluster, id := clusterattr.ImportID(req.ID)
+	node, iface, found := strings.Cut(id, "/")
+	if !found || node == "" || iface == "" || strings.Contains(iface, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: [cluster:]node/iface. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(clusterattr.Name), cluster)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node"), node)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("iface"), iface)...)
+}
+
+// commit runs fn and applies the node pending network changes.
+// When either fails, the pending changes are reverted so they
+// do not get applied later on by someone else. It fails before
+// running fn when the node already has pending changes, as they
+// would be applied or reverted along with the resource ones.
+func (r *NetworkResource) commit(ctx context.Context, client *proxmox.Client, node string, fn func() error) error {
+	// the network changes of a node are serialized, as the
+	// pending changes are applied (or reverted) node wide.
+	release, err := client.Locks.Acquire(ctx, "", "network/"+node)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	changes, err := client.GetNodeNetworkChanges(ctx, node)
+	if err != nil {
+		return err
+	}
+	if changes != "" {
+		return fmt.Errorf("node %s has pending network changes, apply or revert them before running terraform:\n%s", node, changes)
+	}
+
+	err = fn()
+	if err == nil {
+		err = client.ApplyNodeNetwork(ctx, node)
+	}
+	if err != nil {
+		if revertErr := client.RevertNodeNetwork(ctx, node); revertErr != nil {
+			tflog.Error(ctx, "unable to revert node network pending changes", map[string]any{"node": node, "error": revertErr.Error()})
+		}
+		return err
+	}
+
+	return nil
+}
+
+// read loads the remote interface config into data.
+func (r *NetworkResource) read(ctx context.Context, client *proxmox.Client, data *NetworkResourceModel) error {
+	remote, err := client.GetNodeNetwork(ctx, data.Node.ValueString(), data.Iface.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Node.ValueString(), remote.Iface))
+	data.Type = types.StringValue(remote.Type)
+	data.Autostart = types.BoolValue(remote.Autostart == 1)
+	data.CIDR = optional.String(remote.CIDR)
+	data.Gateway = optional.String(remote.Gateway)
+	data.CIDR6 = optional.String(remote.CIDR6)
+	data.Gateway6 = optional.String(remote.Gateway6)
+	data.MTU = optional.Int64(remote.MTU)
+	data.Comments = optional.String(strings.TrimSuffix(remote.Comments, "\n"))
+	data.VlanAware = types.BoolValue(remote.BridgeVlanAware == 1)
+	data.VlanID = optional.Int64(remote.VlanID)
+	data.VlanRawDevice = optional.String(remote.VlanRawDevice)
+	data.BondMode = optional.String(remote.BondMode)
+	data.BondPrimary = optional.String(remote.BondPrimary)
+	data.BondXmitHashPolicy = optional.String(remote.BondXmitHashPolicy)
+	data.OVSBridge = optional.String(remote.OVSBridge)
+	data.OVSTag = optional.Int64(remote.OVSTag)
+	data.OVSOptions = optional.String(remote.OVSOptions)
+
+	ports := ""
+	switch remote.Type {
+	case "bridge":
+		ports = remote.BridgePorts
+	case "bond":
+		ports = remote.Slaves
+	case "OVSBridge":
+		ports = remote.OVSPorts
+	case "OVSBond":
+		ports = remote.OVSBonds
+	}
+	data.Ports = types.ListNull(types.StringType)
+	if ports != "" {
+		items := []attr.Value{}
+		for _, port := range strings.Fields(ports) {
+			items = append(items, types.StringValue(port))
+		}
+		data.Ports = types.ListValueMust(types.StringType, items)
+	}
+
+	return nil
+}
+
+// toRequest maps the model to the proxmox create/update
+// request. The ports are sent using the type specific param.
+func (m NetworkResourceModel) toRequest() proxmox.NodeNetworkRequest {
+	req := proxmox.NodeNetworkRequest{
+		Node:               m.Node.ValueString(),
+		Iface:              m.Iface.ValueString(),
+		Type:               m.Type.ValueString(),
+		Autostart:          m.Autostart.ValueBoolPointer(),
+		CIDR:               m.CIDR.ValueStringPointer(),
+		Gateway:            m.Gateway.ValueStringPointer(),
+		CIDR6:              m.CIDR6.ValueStringPointer(),
+		Gateway6:           m.Gateway6.ValueStringPointer(),
+		MTU:                m.MTU.ValueInt64Pointer(),
+		Comments:           m.Comments.ValueStringPointer(),
+		VlanID:             m.VlanID.ValueInt64Pointer(),
+		VlanRawDevice:      m.VlanRawDevice.ValueStringPointer(),
+		BondMode:           m.BondMode.ValueStringPointer(),
+		BondPrimary:        m.BondPrimary.ValueStringPointer(),
+		BondXmitHashPolicy: m.BondXmitHashPolicy.ValueStringPointer(),
+		OVSBridge:          m.OVSBridge.ValueStringPointer(),
+		OVSTag:             m.OVSTag.ValueInt64Pointer(),
+		OVSOptions:         m.OVSOptions.ValueStringPointer(),
+	}
+
+	if m.Type.ValueString() == "bridge" {
+		req.BridgeVlanAware = m.VlanAware.ValueBoolPointer()
+	}
+
+	if !m.Ports.IsNull() {
+		items := []string{}
+		for _, port := range m.Ports.Elements() {
+			items = append(items, port.(types.String).ValueString())
+		}
+		ports := strings.Join(items, " ")
+
+		switch m.Type.ValueString() {
+		case "bridge":
+			req.BridgePorts = &ports
+		case "bond":
+			req.Slaves = &ports
+		case "OVSBridge":
+			req.OVSPorts = &ports
+		case "OVSBond":
+			req.OVSBonds = &ports
+		}
+	}
+
+	return req
+}
+
+// deleted returns the proxmox params of the attributes that
+// were removed from the configuration.
+func (m NetworkResourceModel) deleted(state NetworkResourceModel) []string {
+	params := []struct {
+		name    string
+		planned attr.Value
+		current attr.Value
+	}{
+		{"cidr", m.CIDR, state.CIDR},
+		{"gateway", m.Gateway, state.Gateway},
+		{"cidr6", m.CIDR6, state.CIDR6},
+		{"gateway6", m.Gateway6, state.Gateway6},
+		{"mtu", m.MTU, state.MTU},
+		{"comments", m.Comments, state.Comments},
+		{"vlan-id", m.VlanID, state.VlanID},
+		{"vlan-raw-device", m.VlanRawDevice, state.VlanRawDevice},
+		{"bond_mode", m.BondMode, state.BondMode},
+		{"bond-primary", m.BondPrimary, state.BondPrimary},
+		{"bond_xmit_hash_policy", m.BondXmitHashPolicy, state.BondXmitHashPolicy},
+		{"ovs_bridge", m.OVSBridge, state.OVSBridge},
+		{"ovs_tag", m.OVSTag, state.OVSTag},
+		{"ovs_options", m.OVSOptions, state.OVSOptions},
+	}
+
+	deleted := []string{}
+	for _, param := range params {
+		if param.planned.IsNull() && !param.current.IsNull() {
+			deleted = append(deleted, param.name)
+		}
+	}
+
+	if m.Ports.IsNull() && !state.Ports.IsNull() {
+		switch m.Type.ValueString() {
+		case "bridge":
+			deleted = append(deleted, "bridge_ports")
+		case "bond":
+			deleted = append(deleted, "slaves")
+		case "OVSBridge":
+			deleted = append(deleted, "ovs_ports")
+		case "OVSBond":
+			deleted = append(deleted, "ovs_bonds")
+		}
+	}
+
+	return deleted
+}
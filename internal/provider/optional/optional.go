@@ -0,0 +1,26 @@
+// Package optional maps the proxmox values that are omitted when
+// unset to the optional attribute values, the zero values being
+// mapped to null.
+package optional
+
+import (
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// String returns a null value when value is empty.
+func String(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// Int64 returns a null value when value is 0.
+func Int64(value proxmox.Int) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
@@ -0,0 +1,62 @@
+// Package preflight reports, during the plan, the privileges the
+// provider user lacks to apply a resource change, based on the
+// permissions read by the provider preflight check.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Changes reports whether the plan creates or updates the resource,
+// the privileges are not checked for plans without changes.
+func Changes(req resource.ModifyPlanRequest) bool {
+	return !req.Plan.Raw.IsNull() && !req.Plan.Raw.Equal(req.State.Raw)
+}
+
+// Creates reports whether the plan creates the resource.
+func Creates(req resource.ModifyPlanRequest) bool {
+	return !req.Plan.Raw.IsNull() && req.State.Raw.IsNull()
+}
+
+// Require adds an error to diags when the user of c lacks privilege
+// on all of the acl paths, it is enough that one of them grants it
+// (i.e. /vms/100 or /pool/prod). Nothing is checked when c is nil
+// or its preflight is disabled.
+func Require(c *proxmox.Client, diags *diag.Diagnostics, privilege string, paths ...string) {
+	if c == nil || c.Permissions() == nil {
+		return
+	}
+
+	for _, p := range paths {
+		if c.Permissions().Has(p, privilege) {
+			return
+		}
+	}
+
+	diags.AddError(
+		"Missing Proxmox Privilege",
+		fmt.Sprintf("The Proxmox API user lacks the %s privilege on %s, which is required to apply this change. "+
+			"Grant it to the user (or the api token) or disable the provider preflight.",
+			privilege, strings.Join(paths, " or ")),
+	)
+}
+
+// PlannedString returns the planned value of the string attribute
+// at p, false when it is null or not known yet, so the acl paths
+// built from it are not checked.
+func PlannedString(ctx context.Context, resp *resource.ModifyPlanResponse, p path.Path) (string, bool) {
+	var value types.String
+	diags := resp.Plan.GetAttribute(ctx, p, &value)
+	if diags.HasError() || value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+	return value.ValueString(), true
+}
@@ -2,19 +2,33 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/cluster"
+	clusterfirewall "terraform-provider-proxmox/internal/provider/cluster_firewall"
 	"terraform-provider-proxmox/internal/provider/lxc"
+	"terraform-provider-proxmox/internal/provider/node"
 	nodefirewall "terraform-provider-proxmox/internal/provider/node_firewall"
+	nodenetwork "terraform-provider-proxmox/internal/provider/node_network"
+	"terraform-provider-proxmox/internal/provider/sdn"
+	"terraform-provider-proxmox/internal/provider/storage"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/iolave/go-proxmox/pkg/cloudflare"
-	"github.com/iolave/go-proxmox/pkg/pve"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -37,6 +51,10 @@ type proxmoxProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// client is the configured client, it is closed when the
+	// provider is configured again.
+	client *proxmox.Client
 }
 
 // Metadata returns the provider type name.
@@ -47,14 +65,69 @@ func (p *proxmoxProvider) Metadata(_ context.Context, _ provider.MetadataRequest
 
 // proxmoxProviderModel maps provider schema data to a Go type.
 type proxmoxProviderModel struct {
-	Host               types.String `tfsdk:"host"`
-	Port               types.Int32  `tfsdk:"port"`
-	User               types.String `tfsdk:"user"`
-	TokenName          types.String `tfsdk:"token_name"`
-	Token              types.String `tfsdk:"token"`
-	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
-	CfClientId         types.String `tfsdk:"cf_client_id"`
-	CfClientSecret     types.String `tfsdk:"cf_client_secret"`
+	Host               types.String  `tfsdk:"host"`
+	Port               types.Int32   `tfsdk:"port"`
+	Endpoints          types.List    `tfsdk:"endpoints"`
+	User               types.String  `tfsdk:"user"`
+	TokenName          types.String  `tfsdk:"token_name"`
+	Token              types.String  `tfsdk:"token"`
+	TokenFile          types.String  `tfsdk:"token_file"`
+	CredentialProcess  types.List    `tfsdk:"credential_process"`
+	Username           types.String  `tfsdk:"username"`
+	Password           types.String  `tfsdk:"password"`
+	OTP                types.String  `tfsdk:"otp"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	CACertPEM          types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String  `tfsdk:"ca_cert_file"`
+	TLSFingerprint     types.String  `tfsdk:"tls_fingerprint_sha256"`
+	ClientCertFile     types.String  `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String  `tfsdk:"client_key_file"`
+	CfClientId         types.String  `tfsdk:"cf_client_id"`
+	CfClientSecret     types.String  `tfsdk:"cf_client_secret"`
+	HTTPProxy          types.String  `tfsdk:"http_proxy"`
+	SOCKS5Proxy        types.String  `tfsdk:"socks5_proxy"`
+	SSHTunnel          types.Object  `tfsdk:"ssh_tunnel"`
+	VMIDRange          types.Object  `tfsdk:"vmid_range"`
+	NodeConcurrency    types.Int64   `tfsdk:"node_concurrency"`
+	Retry              types.Object  `tfsdk:"retry"`
+	MaxRequestsPerSec  types.Float64 `tfsdk:"max_requests_per_second"`
+	Defaults           types.Object  `tfsdk:"defaults"`
+	Clusters           types.Map     `tfsdk:"clusters"`
+	Preflight          types.Bool    `tfsdk:"preflight"`
+}
+
+// sshTunnelModel maps the provider ssh_tunnel block.
+type sshTunnelModel struct {
+	Host           types.String `tfsdk:"host"`
+	User           types.String `tfsdk:"user"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	PrivateKeyFile types.String `tfsdk:"private_key_file"`
+	KnownHostsFile types.String `tfsdk:"known_hosts_file"`
+}
+
+// vmidRangeModel maps the provider vmid_range block.
+type vmidRangeModel struct {
+	Min types.Int64 `tfsdk:"min"`
+	Max types.Int64 `tfsdk:"max"`
+}
+
+// retryModel maps the provider retry block.
+type retryModel struct {
+	MaxAttempts types.Int64   `tfsdk:"max_attempts"`
+	Backoff     types.String  `tfsdk:"backoff"`
+	MaxBackoff  types.String  `tfsdk:"max_backoff"`
+	Jitter      types.Float64 `tfsdk:"jitter"`
+	Statuses    types.List    `tfsdk:"statuses"`
+	LockErrors  types.Bool    `tfsdk:"lock_errors"`
+}
+
+// defaultsModel maps the provider defaults block.
+type defaultsModel struct {
+	Node       types.String `tfsdk:"node"`
+	Storage    types.String `tfsdk:"storage"`
+	Pool       types.String `tfsdk:"pool"`
+	Bridge     types.String `tfsdk:"bridge"`
+	Nameserver types.String `tfsdk:"nameserver"`
 }
 
 // Schema defines the provider-level schema for configuration data.
@@ -67,6 +140,16 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 			"port": schema.Int32Attribute{
 				Optional: true,
 			},
+			"endpoints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The api endpoints of the cluster members (host, host:port or " +
+					"https://host:port, the port defaults to port or 8006), can be set with " +
+					"the PROXMOX_ENDPOINTS comma separated environment variable. They take " +
+					"precedence over host. The first healthy endpoint is used and the api " +
+					"calls fail over to the next healthy one on connection errors. Any " +
+					"member serves the calls bound to another node.",
+			},
 			"user": schema.StringAttribute{
 				Optional: true,
 			},
@@ -76,15 +159,227 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 			"token": schema.StringAttribute{
 				Optional: true,
 			},
+			"token_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a file containing the api token, either the secret " +
+					"or the full token (user@realm!name=secret), can be set with the " +
+					"PROXMOX_TOKEN_FILE environment variable. The file is read again when " +
+					"it changes or the api rejects the token. Conflicts with token and " +
+					"credential_process.",
+			},
+			"credential_process": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "A command (and its arguments) printing the api token as json, i.e. " +
+					"{\"token\": \"...\", \"user\": \"...\", \"token_name\": \"...\", " +
+					"\"expiration\": \"2025-01-01T00:00:00Z\"}, can be set with the " +
+					"PROXMOX_CREDENTIAL_PROCESS environment variable (space separated). " +
+					"user and token_name fall back to the provider ones, expiration is " +
+					"optional. The command runs again when the token expires or the api " +
+					"rejects it. Conflicts with token and token_file.",
+			},
+			"username": schema.StringAttribute{
+				Optional: true,
+				Description: "The user (i.e. root@pam) of the ticket authentication, " +
+					"can be set with the PROXMOX_USERNAME environment variable.",
+			},
+			"password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "The password of the ticket authentication, can be set " +
+					"with the PROXMOX_PASSWORD environment variable. When set, the " +
+					"provider api calls use a ticket (renewed automatically) instead " +
+					"of the api token.",
+			},
+			"otp": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "The TOTP second factor of the ticket authentication, can " +
+					"be set with the PROXMOX_OTP environment variable. Either a code " +
+					"or the base32 TOTP secret, the secret allows the provider to log " +
+					"in again when the ticket cannot be renewed.",
+			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				Optional: true,
 			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "The PEM encoded CA bundle trusted instead of the system roots, " +
+					"can be set with the PROXMOX_CA_CERT_PEM environment variable. " +
+					"Conflicts with ca_cert_file.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a PEM encoded CA bundle trusted instead of the " +
+					"system roots, can be set with the PROXMOX_CA_CERT_FILE environment " +
+					"variable. Conflicts with ca_cert_pem.",
+			},
+			"tls_fingerprint_sha256": schema.StringAttribute{
+				Optional: true,
+				Description: "The sha256 fingerprint of the api certificate (i.e. as " +
+					"displayed in the node certificates), can be set with the " +
+					"PROXMOX_TLS_FINGERPRINT_SHA256 environment variable. The pinned " +
+					"certificate is trusted regardless of its chain. Several fingerprints " +
+					"can be separated by commas, i.e. one per node certificate of the " +
+					"endpoints, a certificate matching any of them is trusted.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of the PEM encoded client certificate presented to " +
+					"the api (mutual tls), can be set with the PROXMOX_CLIENT_CERT_FILE " +
+					"environment variable. Requires client_key_file.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "The path of the PEM encoded client certificate key, can be " +
+					"set with the PROXMOX_CLIENT_KEY_FILE environment variable.",
+			},
 			"cf_client_id": schema.StringAttribute{
 				Optional: true,
 			},
 			"cf_client_secret": schema.StringAttribute{
 				Optional: true,
 			},
+			"http_proxy": schema.StringAttribute{
+				Optional: true,
+				Description: "The http proxy (http://[user:password@]host:port) the api is " +
+					"dialed through with CONNECT, can be set with the PROXMOX_HTTP_PROXY " +
+					"environment variable. Conflicts with socks5_proxy and ssh_tunnel.",
+			},
+			"socks5_proxy": schema.StringAttribute{
+				Optional: true,
+				Description: "The socks5 proxy (socks5://[user:password@]host:port) the api " +
+					"is dialed through, can be set with the PROXMOX_SOCKS5_PROXY environment " +
+					"variable. Conflicts with http_proxy and ssh_tunnel.",
+			},
+			"ssh_tunnel": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The bastion the api is dialed through. Conflicts with " +
+					"http_proxy and socks5_proxy.",
+				Attributes: sshTunnelAttributes(),
+			},
+			"vmid_range": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The range of the vmids allocated by the resources " +
+					"whose vmid is not set. Allocations are serialized within " +
+					"the provider and the next free vmid is tried when the api " +
+					"reports that a vmid already exists.",
+				Attributes: map[string]schema.Attribute{
+					"min": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The lowest vmid to allocate, defaults to %d.", proxmox.VMIDMin),
+						Validators: []validator.Int64{
+							validators.Between(proxmox.VMIDMin, proxmox.VMIDMax),
+						},
+					},
+					"max": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The highest vmid to allocate, defaults to %d.", proxmox.VMIDMax),
+						Validators: []validator.Int64{
+							validators.Between(proxmox.VMIDMin, proxmox.VMIDMax),
+						},
+					},
+				},
+			},
+			"node_concurrency": schema.Int64Attribute{
+				Optional: true,
+				Description: "The maximum number of concurrent guest operations " +
+					"(create, clone, start, stop, delete) per node, unlimited " +
+					"by default. Operations on the same guest, and clones of " +
+					"the same source, are always serialized.",
+				Validators: []validator.Int64{
+					validators.Between(1, 1024),
+				},
+			},
+			"retry": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The retry policy of the failed api calls. Calls that " +
+					"are not idempotent (i.e. a guest creation) are only " +
+					"retried on lock errors.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Optional: true,
+						Description: fmt.Sprintf("The number of times a call is sent, 1 disables the retries. "+
+							"Defaults to %d.", proxmox.DefaultRetryPolicy.MaxAttempts),
+						Validators: []validator.Int64{
+							validators.Between(1, 100),
+						},
+					},
+					"backoff": schema.StringAttribute{
+						Optional: true,
+						Description: fmt.Sprintf("The delay before the first retry (i.e. 2s), it is doubled on each retry. "+
+							"Defaults to %s.", proxmox.DefaultRetryPolicy.Backoff),
+					},
+					"max_backoff": schema.StringAttribute{
+						Optional: true,
+						Description: fmt.Sprintf("The maximum delay between two retries. "+
+							"Defaults to %s.", proxmox.DefaultRetryPolicy.MaxBackoff),
+					},
+					"jitter": schema.Float64Attribute{
+						Optional: true,
+						Description: fmt.Sprintf("The fraction of the delays they are randomized by, from 0 to 1. "+
+							"Defaults to %g.", proxmox.DefaultRetryPolicy.Jitter),
+					},
+					"statuses": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Int64Type,
+						Description: "The retryable http statuses, i.e. 500, 502, 503 or 596. " +
+							"Defaults to 502, 503 and 596.",
+					},
+					"lock_errors": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Whether the calls failing because a guest or its config is locked are retried. Defaults to true.",
+					},
+				},
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional: true,
+				Description: "The maximum rate of the api calls made by the provider, " +
+					"unlimited by default.",
+			},
+			"defaults": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The values the resources fall back to when their matching " +
+					"attribute is not set, the resolved values are shown in the plan. Each " +
+					"value only applies to the resources named in its description.",
+				Attributes: defaultsAttributes(),
+			},
+			"clusters": clustersAttribute(),
+			"preflight": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether the provider checks the api connectivity and credentials when " +
+					"it is configured, by reading the api version and the user (or api token) " +
+					"permissions. The resources then report the privileges missing to apply " +
+					"their changes during the plan, naming the acl path and privilege. The " +
+					"clusters are checked when they are first used. Defaults to false.",
+			},
+		},
+	}
+}
+
+// defaultsAttributes returns the attributes of the defaults blocks.
+func defaultsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"node": schema.StringAttribute{
+			Optional:    true,
+			Description: "The node of the lxc, lxc template, linked clone and os template resources.",
+		},
+		"storage": schema.StringAttribute{
+			Optional:    true,
+			Description: "The storage of the lxc os template resources.",
+		},
+		"pool": schema.StringAttribute{
+			Optional:    true,
+			Description: "The pool of the lxc linked clone resources.",
+		},
+		"bridge": schema.StringAttribute{
+			Optional:    true,
+			Description: "The bridge of the lxc and lxc template networks.",
+		},
+		"nameserver": schema.StringAttribute{
+			Optional:    true,
+			Description: "The nameserver of the lxc and lxc template resources.",
 		},
 	}
 }
@@ -120,6 +415,15 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.Endpoints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Unknown Proxmox API Endpoints",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API endpoints. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_ENDPOINTS environment variable.",
+		)
+	}
+
 	if config.User.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("user"),
@@ -147,6 +451,51 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.TokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unknown Proxmox API Token file",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API token file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_TOKEN_FILE environment variable.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown Proxmox API Credential process",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API credential process. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_CREDENTIAL_PROCESS environment variable.",
+		)
+	}
+
+	if config.Username.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown Proxmox API Username",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API username. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_USERNAME environment variable.",
+		)
+	}
+
+	if config.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Unknown Proxmox API Password",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API password. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_PASSWORD environment variable.",
+		)
+	}
+
+	if config.OTP.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("otp"),
+			"Unknown Proxmox API OTP",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API otp. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_OTP environment variable.",
+		)
+	}
+
 	if config.InsecureSkipVerify.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("insecure_skip_verify"),
@@ -155,6 +504,105 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.CACertPEM.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown Proxmox API CA certificate",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API CA certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_CA_CERT_PEM environment variable.",
+		)
+	}
+
+	if config.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown Proxmox API CA certificate file",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API CA certificate file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_CA_CERT_FILE environment variable.",
+		)
+	}
+
+	if config.TLSFingerprint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_fingerprint_sha256"),
+			"Unknown Proxmox API TLS fingerprint",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API TLS fingerprint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_TLS_FINGERPRINT_SHA256 environment variable.",
+		)
+	}
+
+	if config.ClientCertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert_file"),
+			"Unknown Proxmox API client certificate file",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API client certificate file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_CLIENT_CERT_FILE environment variable.",
+		)
+	}
+
+	if config.ClientKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_key_file"),
+			"Unknown Proxmox API client key file",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API client key file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_CLIENT_KEY_FILE environment variable.",
+		)
+	}
+
+	if config.HTTPProxy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("http_proxy"),
+			"Unknown Proxmox API HTTP proxy",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API http proxy. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_HTTP_PROXY environment variable.",
+		)
+	}
+
+	if config.SOCKS5Proxy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("socks5_proxy"),
+			"Unknown Proxmox API SOCKS5 proxy",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API socks5 proxy. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PROXMOX_SOCKS5_PROXY environment variable.",
+		)
+	}
+
+	if config.SSHTunnel.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssh_tunnel"),
+			"Unknown Proxmox API SSH tunnel",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the Proxmox API ssh tunnel. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Defaults.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("defaults"),
+			"Unknown Proxmox provider defaults",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the provider defaults. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Clusters.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("clusters"),
+			"Unknown Proxmox provider clusters",
+			"The provider cannot create the Proxmox API clients as there is an unknown configuration value for the provider clusters. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Preflight.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preflight"),
+			"Unknown Proxmox provider preflight",
+			"The provider cannot create the Proxmox API client as there is an unknown configuration value for the provider preflight. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if config.CfClientId.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("cf_client_id"),
@@ -195,10 +643,26 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 			)
 		}
 	}
+	endpointValues := []string{}
+	if endpointsEnv := os.Getenv("PROXMOX_ENDPOINTS"); endpointsEnv != "" {
+		endpointValues = strings.Split(endpointsEnv, ",")
+	}
 	user := os.Getenv("PROXMOX_USER")
 	tokenName := os.Getenv("PROXMOX_TOKEN_NAME")
 	token := os.Getenv("PROXMOX_TOKEN")
+	tokenFile := os.Getenv("PROXMOX_TOKEN_FILE")
+	credentialProcess := strings.Fields(os.Getenv("PROXMOX_CREDENTIAL_PROCESS"))
+	username := os.Getenv("PROXMOX_USERNAME")
+	password := os.Getenv("PROXMOX_PASSWORD")
+	otp := os.Getenv("PROXMOX_OTP")
 	insecureSkipVerify := false
+	caCertPEM := os.Getenv("PROXMOX_CA_CERT_PEM")
+	caCertFile := os.Getenv("PROXMOX_CA_CERT_FILE")
+	tlsFingerprint := os.Getenv("PROXMOX_TLS_FINGERPRINT_SHA256")
+	clientCertFile := os.Getenv("PROXMOX_CLIENT_CERT_FILE")
+	clientKeyFile := os.Getenv("PROXMOX_CLIENT_KEY_FILE")
+	httpProxy := os.Getenv("PROXMOX_HTTP_PROXY")
+	socks5Proxy := os.Getenv("PROXMOX_SOCKS5_PROXY")
 	cfClientId := os.Getenv("CF_CLIENT_ID")
 	cfClientSecret := os.Getenv("CF_CLIENT_SECRET")
 
@@ -210,6 +674,11 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		port = int(config.Port.ValueInt32())
 	}
 
+	if !config.Endpoints.IsNull() {
+		endpointValues = []string{}
+		resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpointValues, false)...)
+	}
+
 	if !config.User.IsNull() {
 		user = config.User.ValueString()
 	}
@@ -222,6 +691,35 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		token = config.Token.ValueString()
 	}
 
+	if !config.TokenFile.IsNull() {
+		tokenFile = config.TokenFile.ValueString()
+	}
+
+	if !config.CredentialProcess.IsNull() {
+		credentialProcess = []string{}
+		resp.Diagnostics.Append(config.CredentialProcess.ElementsAs(ctx, &credentialProcess, false)...)
+	}
+
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	if !config.OTP.IsNull() {
+		otp = config.OTP.ValueString()
+	}
+
+	if !config.HTTPProxy.IsNull() {
+		httpProxy = config.HTTPProxy.ValueString()
+	}
+
+	if !config.SOCKS5Proxy.IsNull() {
+		socks5Proxy = config.SOCKS5Proxy.ValueString()
+	}
+
 	if !config.CfClientId.IsNull() {
 		cfClientId = config.CfClientId.ValueString()
 	}
@@ -234,10 +732,30 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		insecureSkipVerify = config.InsecureSkipVerify.ValueBool()
 	}
 
+	if !config.CACertPEM.IsNull() {
+		caCertPEM = config.CACertPEM.ValueString()
+	}
+
+	if !config.CACertFile.IsNull() {
+		caCertFile = config.CACertFile.ValueString()
+	}
+
+	if !config.TLSFingerprint.IsNull() {
+		tlsFingerprint = config.TLSFingerprint.ValueString()
+	}
+
+	if !config.ClientCertFile.IsNull() {
+		clientCertFile = config.ClientCertFile.ValueString()
+	}
+
+	if !config.ClientKeyFile.IsNull() {
+		clientKeyFile = config.ClientKeyFile.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if host == "" {
+	if host == "" && len(endpointValues) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing Proxmox API Host",
@@ -247,7 +765,7 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if port == 0 {
+	if port == 0 && len(endpointValues) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("port"),
 			"Missing Proxmox API Port",
@@ -257,32 +775,61 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if user == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("user"),
-			"Missing Proxmox API User",
-			"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API user. "+
-				"Set the user value in the configuration or use the PROXMOX_USER environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+	tokenSources := 0
+	for _, set := range []bool{token != "", tokenFile != "", len(credentialProcess) > 0} {
+		if set {
+			tokenSources++
+		}
+	}
+	if tokenSources > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting Proxmox API Token sources",
+			"The provider cannot create the Proxmox API client as only one of token, token_file and credential_process "+
+				"(or the matching environment variables) can be set.",
 		)
 	}
 
-	if tokenName == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("tokenName"),
-			"Missing Proxmox API Token name",
-			"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API token name. "+
-				"Set the token name value in the configuration or use the PROXMOX_TOKEN_NAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
+	// The api token is only required when the ticket
+	// authentication is not used, the credential process
+	// might return the user and token name.
+	if password == "" && len(credentialProcess) == 0 {
+		if user == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user"),
+				"Missing Proxmox API User",
+				"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API user. "+
+					"Set the user value in the configuration or use the PROXMOX_USER environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if tokenName == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tokenName"),
+				"Missing Proxmox API Token name",
+				"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API token name. "+
+					"Set the token name value in the configuration or use the PROXMOX_TOKEN_NAME environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if token == "" && tokenFile == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token"),
+				"Missing Proxmox API Token",
+				"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API token. "+
+					"Set the token, token_file or credential_process value in the configuration or use the matching environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
 	}
 
-	if token == "" {
+	if password != "" && username == "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("token"),
-			"Missing Proxmox API Token",
-			"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API token. "+
-				"Set the token value in the configuration or use the PROXMOX_TOKEN environment variable. "+
+			path.Root("username"),
+			"Missing Proxmox API Username",
+			"The provider cannot create the Proxmox API client as there is a missing or empty value for the Proxmox API username. "+
+				"Set the username value in the configuration or use the PROXMOX_USERNAME environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -307,23 +854,238 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	defaultPort := port
+	if defaultPort == 0 {
+		defaultPort = 8006
+	}
+	endpoints := []proxmox.Endpoint{}
+	for _, value := range endpointValues {
+		endpoint, err := proxmox.ParseEndpoint(value, defaultPort)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoints"),
+				"Invalid Proxmox API Endpoint",
+				"The provider cannot create the Proxmox API client as an endpoint is invalid: "+err.Error(),
+			)
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
 	}
 
-	// Create a new Proxmox client using the configuration values
-	pveConfig := pve.Config{
-		Host:               host,
-		Port:               port,
-		InsecureSkipVerify: insecureSkipVerify,
+	if caCertPEM != "" && caCertFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Conflicting Proxmox API CA certificate",
+			"The provider cannot create the Proxmox API client as both the CA certificate pem and file are set. "+
+				"Set either the ca_cert_pem or the ca_cert_file value (or the matching environment variable).",
+		)
+	}
+
+	if caCertFile != "" {
+		b, err := os.ReadFile(caCertFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid Proxmox API CA certificate file",
+				"The provider cannot read the CA certificate file: "+err.Error(),
+			)
+		}
+		caCertPEM = string(b)
+	}
+
+	if tlsFingerprint != "" {
+		if _, err := proxmox.ParseFingerprints(tlsFingerprint); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls_fingerprint_sha256"),
+				"Invalid Proxmox API TLS fingerprint",
+				"The provider cannot create the Proxmox API client as the TLS fingerprint is not a sha256 fingerprint, "+
+					"expected 32 hex encoded bytes optionally separated by colons, several fingerprints separated by commas.",
+			)
+		}
+	}
+
+	if insecureSkipVerify && (caCertPEM != "" || tlsFingerprint != "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Conflicting Proxmox API TLS verification",
+			"The provider cannot create the Proxmox API client as insecure_skip_verify disables the certificate verification, "+
+				"it cannot be combined with a CA certificate or a TLS fingerprint.",
+		)
+	}
+
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert_file"),
+			"Missing Proxmox API client certificate",
+			"The provider cannot create the Proxmox API client as the client certificate and key files must be set together.",
+		)
+	}
+
+	var clientCertPEM, clientKeyPEM []byte
+	if clientCertFile != "" && clientKeyFile != "" {
+		if clientCertPEM, err = os.ReadFile(clientCertFile); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_cert_file"),
+				"Invalid Proxmox API client certificate file",
+				"The provider cannot read the client certificate file: "+err.Error(),
+			)
+		}
+		if clientKeyPEM, err = os.ReadFile(clientKeyFile); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_key_file"),
+				"Invalid Proxmox API client key file",
+				"The provider cannot read the client key file: "+err.Error(),
+			)
+		}
+	}
+
+	var sshTunnel *proxmox.SSHTunnel
+	if !config.SSHTunnel.IsNull() && !config.SSHTunnel.IsUnknown() {
+		sshTunnel = sshTunnelFromModel(ctx, config.SSHTunnel, path.Root("ssh_tunnel"), &resp.Diagnostics)
+	}
+
+	transports := 0
+	for _, set := range []bool{httpProxy != "", socks5Proxy != "", sshTunnel != nil} {
+		if set {
+			transports++
+		}
+	}
+	if transports > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting Proxmox API transports",
+			"The provider cannot create the Proxmox API client as only one of http_proxy, socks5_proxy and ssh_tunnel "+
+				"(or the matching environment variables) can be set.",
+		)
+	}
+
+	vmidRange := proxmox.DefaultVMIDRange
+	if !config.VMIDRange.IsNull() && !config.VMIDRange.IsUnknown() {
+		var rangeConfig vmidRangeModel
+		resp.Diagnostics.Append(config.VMIDRange.As(ctx, &rangeConfig, basetypes.ObjectAsOptions{})...)
+		if !rangeConfig.Min.IsNull() {
+			vmidRange.Min = int(rangeConfig.Min.ValueInt64())
+		}
+		if !rangeConfig.Max.IsNull() {
+			vmidRange.Max = int(rangeConfig.Max.ValueInt64())
+		}
+		if err := vmidRange.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("vmid_range"),
+				"Invalid Proxmox VMID Range",
+				"The provider cannot create the Proxmox API client as the vmid range is invalid: "+err.Error(),
+			)
+		}
+	}
+
+	retryPolicy := proxmox.DefaultRetryPolicy
+	if !config.Retry.IsNull() && !config.Retry.IsUnknown() {
+		var retryConfig retryModel
+		resp.Diagnostics.Append(config.Retry.As(ctx, &retryConfig, basetypes.ObjectAsOptions{})...)
+		if !retryConfig.MaxAttempts.IsNull() {
+			retryPolicy.MaxAttempts = int(retryConfig.MaxAttempts.ValueInt64())
+		}
+		for name, value := range map[string]types.String{"backoff": retryConfig.Backoff, "max_backoff": retryConfig.MaxBackoff} {
+			if value.IsNull() {
+				continue
+			}
+			d, err := time.ParseDuration(value.ValueString())
+			if err != nil || d < 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("retry").AtName(name),
+					"Invalid Proxmox API Retry Policy",
+					fmt.Sprintf("The provider cannot create the Proxmox API client as the %s is not a valid duration, i.e. 2s.", name),
+				)
+				continue
+			}
+			if name == "backoff" {
+				retryPolicy.Backoff = d
+			} else {
+				retryPolicy.MaxBackoff = d
+			}
+		}
+		if !retryConfig.Jitter.IsNull() {
+			retryPolicy.Jitter = retryConfig.Jitter.ValueFloat64()
+			if retryPolicy.Jitter < 0 || retryPolicy.Jitter > 1 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("retry").AtName("jitter"),
+					"Invalid Proxmox API Retry Policy",
+					"The provider cannot create the Proxmox API client as the jitter must be between 0 and 1.",
+				)
+			}
+		}
+		if !retryConfig.Statuses.IsNull() && !retryConfig.Statuses.IsUnknown() {
+			statuses := []int64{}
+			resp.Diagnostics.Append(retryConfig.Statuses.ElementsAs(ctx, &statuses, false)...)
+			retryPolicy.Statuses = []int{}
+			for _, status := range statuses {
+				if status < 400 || status > 599 {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("retry").AtName("statuses"),
+						"Invalid Proxmox API Retry Policy",
+						fmt.Sprintf("The provider cannot create the Proxmox API client as %d is not an http error status.", status),
+					)
+				}
+				retryPolicy.Statuses = append(retryPolicy.Statuses, int(status))
+			}
+		}
+		if !retryConfig.LockErrors.IsNull() {
+			retryPolicy.LockErrors = retryConfig.LockErrors.ValueBool()
+		}
+	}
+
+	var defaults proxmox.Defaults
+	if !config.Defaults.IsNull() && !config.Defaults.IsUnknown() {
+		defaults = defaultsFromModel(ctx, config.Defaults, &resp.Diagnostics)
+	}
+
+	if config.MaxRequestsPerSec.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Invalid Proxmox API Rate Limit",
+			"The provider cannot create the Proxmox API client as max_requests_per_second must be positive.",
+		)
+	}
+
+	clientConfig := proxmox.Config{
+		Host:                 host,
+		Port:                 port,
+		InsecureSkipVerify:   insecureSkipVerify,
+		CACertPEM:            caCertPEM,
+		TLSFingerprintSHA256: tlsFingerprint,
+		ClientCertPEM:        string(clientCertPEM),
+		ClientKeyPEM:         string(clientKeyPEM),
+		Endpoints:            endpoints,
+		User:                 user,
+		TokenName:            tokenName,
+		Token:                token,
+		TokenFile:            tokenFile,
+		CredentialProcess:    credentialProcess,
+		Username:             username,
+		Password:             password,
+		OTP:                  otp,
+		CfClientID:           cfClientId,
+		CfClientSecret:       cfClientSecret,
+		HTTPProxy:            httpProxy,
+		SOCKS5Proxy:          socks5Proxy,
+		SSHTunnel:            sshTunnel,
+		VMIDRange:            vmidRange,
+		NodeConcurrency:      int(config.NodeConcurrency.ValueInt64()),
+		RetryPolicy:          &retryPolicy,
+		MaxRequestsPerSecond: config.MaxRequestsPerSec.ValueFloat64(),
+		Defaults:             defaults,
+		Preflight:            config.Preflight.ValueBool(),
 	}
-	if cfClientId != "" && cfClientSecret != "" {
-		cfServiceToken := cloudflare.NewServiceToken(cfClientId, cfClientSecret)
-		pveConfig.CfServiceToken = cfServiceToken
+
+	if !config.Clusters.IsNull() && !config.Clusters.IsUnknown() {
+		clientConfig.Clusters = clusterConfigs(ctx, config.Clusters, clientConfig, &resp.Diagnostics)
 	}
 
-	creds := pve.NewTokenCreds(user, tokenName, token)
-	pve, err := pve.NewWithCredentials(pveConfig, creds)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create a new Proxmox client using the configuration values
+	client, err := proxmox.New(ctx, clientConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Proxmox API Client",
@@ -334,10 +1096,145 @@ func (p *proxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	if len(endpoints) > 0 {
+		endpoint, err := client.SelectEndpoint(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoints"),
+				"Unable to Reach Proxmox API",
+				"The provider cannot reach any of the Proxmox API endpoints.\n\n"+
+					"Proxmox Client Error: "+err.Error(),
+			)
+			client.Close()
+			return
+		}
+		tflog.Info(ctx, "Selected Proxmox API endpoint", map[string]any{"endpoint": endpoint.String()})
+	}
+
+	if err := client.Login(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Authenticate to Proxmox API",
+			"The provider cannot obtain a Proxmox API ticket with the configured username and password.\n\n"+
+				"Proxmox Client Error: "+err.Error(),
+		)
+		client.Close()
+		return
+	}
+
+	if config.Preflight.ValueBool() {
+		version, err := client.Preflight(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Proxmox API Preflight Failed",
+				"The provider cannot read the Proxmox API version or the permissions of its user, "+
+					"check the api connectivity and credentials.\n\n"+
+					"Proxmox Client Error: "+err.Error(),
+			)
+			client.Close()
+			return
+		}
+		tflog.Info(ctx, "Proxmox API preflight succeeded", map[string]any{"version": version.Version, "release": version.Release})
+	}
+
+	tflog.Info(ctx, "Configured Proxmox API client", map[string]any{"auth_mode": client.AuthMode()})
+
+	if p.client != nil {
+		p.client.Close()
+	}
+	p.client = client
+
 	// Make the Proxmox client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = pve
-	resp.ResourceData = pve
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// sshTunnelAttributes returns the attributes of the provider (and
+// clusters) ssh_tunnel block.
+func sshTunnelAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"host": schema.StringAttribute{
+			Required:    true,
+			Description: "The bastion address, host or host:port (port 22 by default).",
+		},
+		"user": schema.StringAttribute{
+			Required:    true,
+			Description: "The bastion user.",
+		},
+		"private_key": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The PEM encoded (unencrypted) private key of the user. Conflicts with private_key_file.",
+		},
+		"private_key_file": schema.StringAttribute{
+			Optional:    true,
+			Description: "The path of the private key of the user. Conflicts with private_key.",
+		},
+		"known_hosts_file": schema.StringAttribute{
+			Optional:    true,
+			Description: "The known_hosts file the bastion host key is verified with, defaults to ~/.ssh/known_hosts.",
+		},
+	}
+}
+
+// sshTunnelFromModel maps an ssh_tunnel block, p is its path. The
+// private key file is read and the known_hosts file defaults to the
+// user one.
+func sshTunnelFromModel(ctx context.Context, value types.Object, p path.Path, diags *diag.Diagnostics) *proxmox.SSHTunnel {
+	var tunnelConfig sshTunnelModel
+	diags.Append(value.As(ctx, &tunnelConfig, basetypes.ObjectAsOptions{})...)
+	sshTunnel := &proxmox.SSHTunnel{
+		Host:           tunnelConfig.Host.ValueString(),
+		User:           tunnelConfig.User.ValueString(),
+		PrivateKeyPEM:  tunnelConfig.PrivateKey.ValueString(),
+		KnownHostsFile: tunnelConfig.KnownHostsFile.ValueString(),
+	}
+
+	if tunnelConfig.PrivateKey.IsNull() == tunnelConfig.PrivateKeyFile.IsNull() {
+		diags.AddAttributeError(
+			p.AtName("private_key"),
+			"Invalid Proxmox API SSH tunnel",
+			"The provider cannot create the Proxmox API client as either the ssh tunnel private_key or private_key_file must be set.",
+		)
+	}
+	if !tunnelConfig.PrivateKeyFile.IsNull() {
+		b, err := os.ReadFile(tunnelConfig.PrivateKeyFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				p.AtName("private_key_file"),
+				"Invalid Proxmox API SSH tunnel",
+				"The provider cannot read the ssh tunnel private key file: "+err.Error(),
+			)
+		}
+		sshTunnel.PrivateKeyPEM = string(b)
+	}
+	if sshTunnel.KnownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			diags.AddAttributeError(
+				p.AtName("known_hosts_file"),
+				"Invalid Proxmox API SSH tunnel",
+				"The provider cannot find the default known_hosts file, set the ssh tunnel known_hosts_file: "+err.Error(),
+			)
+		}
+		sshTunnel.KnownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	return sshTunnel
+}
+
+// defaultsFromModel maps a defaults block.
+func defaultsFromModel(ctx context.Context, value types.Object, diags *diag.Diagnostics) proxmox.Defaults {
+	var defaultsConfig defaultsModel
+	diags.Append(value.As(ctx, &defaultsConfig, basetypes.ObjectAsOptions{})...)
+
+	return proxmox.Defaults{
+		Node:       defaultsConfig.Node.ValueString(),
+		Storage:    defaultsConfig.Storage.ValueString(),
+		Pool:       defaultsConfig.Pool.ValueString(),
+		Bridge:     defaultsConfig.Bridge.ValueString(),
+		Nameserver: defaultsConfig.Nameserver.ValueString(),
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -345,6 +1242,12 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 	return []func() datasource.DataSource{
 		NewVersionDataSource,
 		nodefirewall.NewRulesDataSource,
+		storage.NewContentDataSource,
+		node.NewNodesDataSource,
+		node.NewStatusDataSource,
+		cluster.NewResourcesDataSource,
+		cluster.NewNextVMIDDataSource,
+		lxc.NewLXCDataSource,
 	}
 }
 
@@ -353,10 +1256,18 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		nodefirewall.NewRulesResource,
 		nodefirewall.NewRuleResource,
+		clusterfirewall.NewOptionsResource,
+		nodenetwork.NewNetworkResource,
+		sdn.NewZoneResource,
+		sdn.NewVnetResource,
+		sdn.NewSubnetResource,
+		storage.NewStorageResource,
+		storage.NewFileResource,
 		lxc.NewLXCResource("lxc"),
 		lxc.NewLXCResource("node_lxc"),
 		lxc.NewLXCExecResource,
 		lxc.NewLXCTplResource("lxc_template"),
 		lxc.NewLXCLinkedCloneResource,
+		lxc.NewLXCOSTplResource,
 	}
 }
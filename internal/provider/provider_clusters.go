@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"sort"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// clusterModel maps an entry of the provider clusters map.
+type clusterModel struct {
+	Host               types.String `tfsdk:"host"`
+	Port               types.Int32  `tfsdk:"port"`
+	Endpoints          types.List   `tfsdk:"endpoints"`
+	User               types.String `tfsdk:"user"`
+	TokenName          types.String `tfsdk:"token_name"`
+	Token              types.String `tfsdk:"token"`
+	TokenFile          types.String `tfsdk:"token_file"`
+	CredentialProcess  types.List   `tfsdk:"credential_process"`
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	OTP                types.String `tfsdk:"otp"`
+	CfClientID         types.String `tfsdk:"cf_client_id"`
+	CfClientSecret     types.String `tfsdk:"cf_client_secret"`
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+	SOCKS5Proxy        types.String `tfsdk:"socks5_proxy"`
+	SSHTunnel          types.Object `tfsdk:"ssh_tunnel"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	TLSFingerprint     types.String `tfsdk:"tls_fingerprint_sha256"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	Defaults           types.Object `tfsdk:"defaults"`
+}
+
+// clustersAttribute returns the provider clusters attribute.
+func clustersAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional: true,
+		Description: "The other clusters managed by the provider, keyed by the name the " +
+			"resources and data sources select them with in their cluster attribute. " +
+			"The resources and data sources without cluster use the provider cluster. " +
+			"A cluster inherits the provider retry, max_requests_per_second, " +
+			"node_concurrency, vmid_range, defaults and preflight settings, its " +
+			"connection, authentication, tls and transport settings are only its own. " +
+			"Its client is only created when a resource or data source uses it.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"host": schema.StringAttribute{
+					Optional:    true,
+					Description: "The api host of the cluster. Either host or endpoints must be set.",
+				},
+				"port": schema.Int32Attribute{
+					Optional:    true,
+					Description: "The api port of the cluster, defaults to the provider port or 8006.",
+				},
+				"endpoints": schema.ListAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Description: "The api endpoints of the cluster members, as the provider endpoints.",
+				},
+				"user": schema.StringAttribute{
+					Optional: true,
+				},
+				"token_name": schema.StringAttribute{
+					Optional: true,
+				},
+				"token": schema.StringAttribute{
+					Optional:  true,
+					Sensitive: true,
+				},
+				"token_file": schema.StringAttribute{
+					Optional:    true,
+					Description: "The path of a file containing the api token, as the provider token_file.",
+				},
+				"credential_process": schema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Description: "A command printing the api token as json, as the provider credential_process.",
+				},
+				"username": schema.StringAttribute{
+					Optional:    true,
+					Description: "The user of the ticket authentication.",
+				},
+				"password": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The password of the ticket authentication.",
+				},
+				"otp": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The TOTP second factor of the ticket authentication.",
+				},
+				"cf_client_id": schema.StringAttribute{
+					Optional:    true,
+					Description: "The cloudflare client id, when the cluster api is secured by cloudflare.",
+				},
+				"cf_client_secret": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The cloudflare client secret, when the cluster api is secured by cloudflare.",
+				},
+				"http_proxy": schema.StringAttribute{
+					Optional:    true,
+					Description: "The http proxy the cluster api is dialed through, as the provider http_proxy.",
+				},
+				"socks5_proxy": schema.StringAttribute{
+					Optional:    true,
+					Description: "The socks5 proxy the cluster api is dialed through, as the provider socks5_proxy.",
+				},
+				"ssh_tunnel": schema.SingleNestedAttribute{
+					Optional:    true,
+					Description: "The bastion the cluster api is dialed through, as the provider ssh_tunnel.",
+					Attributes:  sshTunnelAttributes(),
+				},
+				"insecure_skip_verify": schema.BoolAttribute{
+					Optional: true,
+				},
+				"ca_cert_pem": schema.StringAttribute{
+					Optional:    true,
+					Description: "The PEM encoded CA bundle trusted instead of the system roots. Conflicts with ca_cert_file.",
+				},
+				"ca_cert_file": schema.StringAttribute{
+					Optional:    true,
+					Description: "The path of a PEM encoded CA bundle trusted instead of the system roots. Conflicts with ca_cert_pem.",
+				},
+				"tls_fingerprint_sha256": schema.StringAttribute{
+					Optional:    true,
+					Description: "The sha256 fingerprint of the api certificate, several fingerprints (i.e. one per node certificate of the endpoints) can be separated by commas.",
+				},
+				"client_cert_file": schema.StringAttribute{
+					Optional:    true,
+					Description: "The path of the PEM encoded client certificate presented to the cluster api. Requires client_key_file.",
+				},
+				"client_key_file": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The path of the PEM encoded client certificate key.",
+				},
+				"defaults": schema.SingleNestedAttribute{
+					Optional:    true,
+					Description: "The defaults of the cluster resources, replaces the provider defaults.",
+					Attributes:  defaultsAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// clusterConfigs returns the client configs of the provider clusters
+// map. The configs only copy the retry, rate, concurrency, vmid range,
+// defaults and preflight settings of base, the provider cluster
+// config, the others are their own.
+func clusterConfigs(ctx context.Context, clusters types.Map, base proxmox.Config, diags *diag.Diagnostics) map[string]proxmox.Config {
+	models := map[string]clusterModel{}
+	diags.Append(clusters.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	configs := map[string]proxmox.Config{}
+	for _, name := range names {
+		configs[name] = clusterConfig(ctx, name, models[name], base, diags)
+	}
+	return configs
+}
+
+func clusterConfig(ctx context.Context, name string, model clusterModel, base proxmox.Config, diags *diag.Diagnostics) proxmox.Config {
+	p := path.Root("clusters").AtMapKey(name)
+
+	cfg := proxmox.Config{
+		VMIDRange:            base.VMIDRange,
+		RetryPolicy:          base.RetryPolicy,
+		MaxRequestsPerSecond: base.MaxRequestsPerSecond,
+		NodeConcurrency:      base.NodeConcurrency,
+		Defaults:             base.Defaults,
+		Preflight:            base.Preflight,
+	}
+	cfg.Host = model.Host.ValueString()
+	cfg.Port = int(model.Port.ValueInt32())
+	if cfg.Port == 0 {
+		cfg.Port = base.Port
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 8006
+	}
+	cfg.User = model.User.ValueString()
+	cfg.TokenName = model.TokenName.ValueString()
+	cfg.Token = model.Token.ValueString()
+	cfg.TokenFile = model.TokenFile.ValueString()
+	cfg.Username = model.Username.ValueString()
+	cfg.Password = model.Password.ValueString()
+	cfg.OTP = model.OTP.ValueString()
+	cfg.CfClientID = model.CfClientID.ValueString()
+	cfg.CfClientSecret = model.CfClientSecret.ValueString()
+	cfg.HTTPProxy = model.HTTPProxy.ValueString()
+	cfg.SOCKS5Proxy = model.SOCKS5Proxy.ValueString()
+	cfg.InsecureSkipVerify = model.InsecureSkipVerify.ValueBool()
+	cfg.CACertPEM = model.CACertPEM.ValueString()
+	cfg.TLSFingerprintSHA256 = model.TLSFingerprint.ValueString()
+
+	if !model.SSHTunnel.IsNull() && !model.SSHTunnel.IsUnknown() {
+		cfg.SSHTunnel = sshTunnelFromModel(ctx, model.SSHTunnel, p.AtName("ssh_tunnel"), diags)
+	}
+
+	if !model.CredentialProcess.IsNull() {
+		diags.Append(model.CredentialProcess.ElementsAs(ctx, &cfg.CredentialProcess, false)...)
+	}
+
+	if !model.Endpoints.IsNull() {
+		values := []string{}
+		diags.Append(model.Endpoints.ElementsAs(ctx, &values, false)...)
+		for _, value := range values {
+			endpoint, err := proxmox.ParseEndpoint(value, cfg.Port)
+			if err != nil {
+				diags.AddAttributeError(
+					p.AtName("endpoints"),
+					"Invalid Proxmox API Endpoint",
+					"The provider cannot create the Proxmox API client of cluster "+name+" as an endpoint is invalid: "+err.Error(),
+				)
+				continue
+			}
+			cfg.Endpoints = append(cfg.Endpoints, endpoint)
+		}
+	}
+
+	if !model.Defaults.IsNull() && !model.Defaults.IsUnknown() {
+		cfg.Defaults = defaultsFromModel(ctx, model.Defaults, diags)
+	}
+
+	if cfg.Host == "" && len(cfg.Endpoints) == 0 {
+		diags.AddAttributeError(
+			p.AtName("host"),
+			"Missing Proxmox API Host",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as both its host and endpoints are empty.",
+		)
+	}
+
+	tokenSources := 0
+	for _, set := range []bool{cfg.Token != "", cfg.TokenFile != "", len(cfg.CredentialProcess) > 0} {
+		if set {
+			tokenSources++
+		}
+	}
+	if tokenSources > 1 {
+		diags.AddAttributeError(
+			p,
+			"Conflicting Proxmox API Token sources",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as only one of token, "+
+				"token_file and credential_process can be set.",
+		)
+	}
+
+	switch {
+	case cfg.Password != "" && cfg.Username == "":
+		diags.AddAttributeError(
+			p.AtName("username"),
+			"Missing Proxmox API Username",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as the username of its password is empty.",
+		)
+	case cfg.Password == "" && len(cfg.CredentialProcess) == 0 &&
+		(cfg.User == "" || cfg.TokenName == "" || tokenSources == 0):
+		diags.AddAttributeError(
+			p,
+			"Missing Proxmox API Token",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as its credentials are missing. "+
+				"Set the user, token_name and token (or token_file), the credential_process or the username and password.",
+		)
+	}
+
+	if cfg.TLSFingerprintSHA256 != "" {
+		if _, err := proxmox.ParseFingerprints(cfg.TLSFingerprintSHA256); err != nil {
+			diags.AddAttributeError(
+				p.AtName("tls_fingerprint_sha256"),
+				"Invalid Proxmox API TLS fingerprint",
+				"The provider cannot create the Proxmox API client of cluster "+name+" as the TLS fingerprint is not a sha256 fingerprint, "+
+					"expected 32 hex encoded bytes optionally separated by colons, several fingerprints separated by commas.",
+			)
+		}
+	}
+
+	if cfg.CACertPEM != "" && !model.CACertFile.IsNull() {
+		diags.AddAttributeError(
+			p.AtName("ca_cert_file"),
+			"Conflicting Proxmox API CA certificate",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as both the CA certificate pem and file are set.",
+		)
+	}
+	if !model.CACertFile.IsNull() {
+		b, err := os.ReadFile(model.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				p.AtName("ca_cert_file"),
+				"Invalid Proxmox API CA certificate file",
+				"The provider cannot read the CA certificate file of cluster "+name+": "+err.Error(),
+			)
+		}
+		cfg.CACertPEM = string(b)
+	}
+
+	if model.ClientCertFile.IsNull() != model.ClientKeyFile.IsNull() {
+		diags.AddAttributeError(
+			p.AtName("client_cert_file"),
+			"Missing Proxmox API client certificate",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as the client certificate and key files must be set together.",
+		)
+	} else if !model.ClientCertFile.IsNull() {
+		b, err := os.ReadFile(model.ClientCertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				p.AtName("client_cert_file"),
+				"Invalid Proxmox API client certificate file",
+				"The provider cannot read the client certificate file of cluster "+name+": "+err.Error(),
+			)
+		}
+		cfg.ClientCertPEM = string(b)
+
+		if b, err = os.ReadFile(model.ClientKeyFile.ValueString()); err != nil {
+			diags.AddAttributeError(
+				p.AtName("client_key_file"),
+				"Invalid Proxmox API client key file",
+				"The provider cannot read the client key file of cluster "+name+": "+err.Error(),
+			)
+		}
+		cfg.ClientKeyPEM = string(b)
+	}
+
+	transports := 0
+	for _, set := range []bool{cfg.HTTPProxy != "", cfg.SOCKS5Proxy != "", cfg.SSHTunnel != nil} {
+		if set {
+			transports++
+		}
+	}
+	if transports > 1 {
+		diags.AddAttributeError(
+			p,
+			"Conflicting Proxmox API transports",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as only one of http_proxy, "+
+				"socks5_proxy and ssh_tunnel can be set.",
+		)
+	}
+
+	if cfg.InsecureSkipVerify && (cfg.CACertPEM != "" || cfg.TLSFingerprintSHA256 != "") {
+		diags.AddAttributeError(
+			p.AtName("insecure_skip_verify"),
+			"Conflicting Proxmox API TLS verification",
+			"The provider cannot create the Proxmox API client of cluster "+name+" as insecure_skip_verify disables "+
+				"the certificate verification, it cannot be combined with a CA certificate or a TLS fingerprint.",
+		)
+	}
+
+	return cfg
+}
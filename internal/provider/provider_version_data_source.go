@@ -3,11 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/proxmox"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/iolave/go-proxmox/pkg/pve"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,7 +24,7 @@ func NewVersionDataSource() datasource.DataSource {
 
 // coffeesDataSource is the data source implementation.
 type versionDataSource struct {
-	client *pve.PVE
+	client *proxmox.Client
 }
 
 // Metadata returns the data source type name.
@@ -33,6 +34,7 @@ func (d *versionDataSource) Metadata(_ context.Context, req datasource.MetadataR
 func (d *versionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
 			"version": schema.StringAttribute{
 				Computed: true,
 			},
@@ -42,6 +44,10 @@ func (d *versionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 			"release": schema.StringAttribute{
 				Computed: true,
 			},
+			"auth_mode": schema.StringAttribute{
+				Computed:    true,
+				Description: "The provider authentication mode, either api_token or ticket.",
+			},
 		},
 	}
 }
@@ -50,7 +56,17 @@ func (d *versionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 func (d *versionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state versionDataSourceModel
 
-	version, err := d.client.GetVersion()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version, err := client.GetVersion(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Proxmox Version",
@@ -62,6 +78,7 @@ func (d *versionDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	state.Release = types.StringValue(version.Release)
 	state.Version = types.StringValue(version.Version)
 	state.RepoID = types.StringValue(version.RepoID)
+	state.AuthMode = types.StringValue(client.AuthMode())
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -79,8 +96,7 @@ func (d *versionDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*pve.PVE)
-	client.GetVersion()
+	client, ok := req.ProviderData.(*proxmox.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
@@ -95,9 +111,11 @@ func (d *versionDataSource) Configure(_ context.Context, req datasource.Configur
 
 // versionDataSourceModel maps the data source schema data.
 type versionDataSourceModel struct {
-	Release types.String `tfsdk:"release"`
-	Version types.String `tfsdk:"version"`
-	RepoID  types.String `tfsdk:"repo_id"`
+	Cluster  types.String `tfsdk:"cluster"`
+	Release  types.String `tfsdk:"release"`
+	Version  types.String `tfsdk:"version"`
+	RepoID   types.String `tfsdk:"repo_id"`
+	AuthMode types.String `tfsdk:"auth_mode"`
 }
 
 // versionModel maps version schema data.
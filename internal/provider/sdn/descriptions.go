@@ -0,0 +1,72 @@
+package sdn
+
+// descriptions for zones
+const (
+	DESC_ZONE = "SDN zone. A zone defines the virtually " +
+		"separated network area vnets belong to.\n" +
+		"Pending sdn changes are applied cluster wide right " +
+		"away, including the ones that were not made by terraform."
+	DESC_ZONE_ID   = "The zone id."
+	DESC_ZONE_ZONE = "The zone name (up to 8 lowercase letters and digits)."
+	DESC_ZONE_TYPE = "Zone type.\n" +
+		"Values: simple | vlan | qinq | vxlan | evpn"
+	DESC_ZONE_NODES = "Nodes the zone is deployed to. " +
+		"All nodes when not set."
+	DESC_ZONE_MTU    = "MTU of the zone vnets."
+	DESC_ZONE_IPAM   = "IP address management plugin, i.e. 'pve'."
+	DESC_ZONE_DHCP   = "DHCP plugin of simple zones.\nValues: dnsmasq"
+	DESC_ZONE_BRIDGE = "Node bridge the vlan tags are added to " +
+		"(vlan and qinq zones)."
+	DESC_ZONE_TAG           = "Service vlan tag (qinq zones)."
+	DESC_ZONE_VLAN_PROTOCOL = "Service vlan protocol (qinq zones).\n" +
+		"Values: 802.1q | 802.1ad"
+	DESC_ZONE_PEERS = "Addresses of the nodes taking part in " +
+		"the vxlan tunnels (vxlan zones)."
+	DESC_ZONE_CONTROLLER = "The evpn controller (evpn zones)."
+	DESC_ZONE_VRF_VXLAN  = "VXLAN id of the zone routing " +
+		"(evpn zones)."
+	DESC_ZONE_EXIT_NODES = "Nodes routing the traffic to the " +
+		"outside (evpn zones)."
+	DESC_ZONE_MAC = "Anycast router mac address (evpn zones)."
+)
+
+// descriptions for vnets
+const (
+	DESC_VNET = "SDN vnet. Guests can be attached to a vnet " +
+		"using its name as bridge.\n" +
+		"Pending sdn changes are applied cluster wide right " +
+		"away, including the ones that were not made by terraform."
+	DESC_VNET_ID    = "The vnet id."
+	DESC_VNET_VNET  = "The vnet name (up to 8 lowercase letters and digits)."
+	DESC_VNET_ZONE  = "The zone the vnet belongs to."
+	DESC_VNET_ALIAS = "Descriptive alias."
+	DESC_VNET_TAG   = "VLAN tag (vlan and qinq zones) or " +
+		"VXLAN id (vxlan and evpn zones)."
+	DESC_VNET_VLAN_AWARE    = "Allow vlans within the vnet."
+	DESC_VNET_ISOLATE_PORTS = "Isolate the vnet ports, so guests " +
+		"can only reach the outside."
+)
+
+// descriptions for subnets
+const (
+	DESC_SUBNET = "SDN vnet subnet.\n" +
+		"Pending sdn changes are applied cluster wide right " +
+		"away, including the ones that were not made by terraform."
+	DESC_SUBNET_ID              = "Proxmox subnet id, i.e. 'zone-10.0.0.0-24'."
+	DESC_SUBNET_VNET            = "The vnet the subnet belongs to."
+	DESC_SUBNET_CIDR            = "Subnet network in cidr notation, i.e. '10.0.0.0/24'."
+	DESC_SUBNET_GATEWAY         = "Subnet gateway address."
+	DESC_SUBNET_SNAT            = "Source nat the traffic leaving the subnet."
+	DESC_SUBNET_DHCP_DNS_SERVER = "DNS server address handed out by dhcp."
+	DESC_SUBNET_DHCP_RANGES     = "DHCP address ranges (requires a zone " +
+		"dhcp plugin)."
+	DESC_SUBNET_DHCP_RANGE_START = "First address of the range."
+	DESC_SUBNET_DHCP_RANGE_END   = "Last address of the range."
+)
+
+// default values
+const (
+	DFLT_VNET_VLAN_AWARE    = false
+	DFLT_VNET_ISOLATE_PORTS = false
+	DFLT_SUBNET_SNAT        = false
+)
@@ -0,0 +1,71 @@
+package sdn
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apply runs fn and applies the pending sdn changes.
+func apply(ctx context.Context, c *proxmox.Client, fn func() error) error {
+	// the sdn changes are serialized, as the pending changes
+	// are applied cluster wide.
+	release, err := c.Locks.Acquire(ctx, "", "sdn")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return c.ApplySDN(ctx)
+}
+
+// joinList formats a string list as a proxmox comma separated
+// list, nil is returned for null lists.
+func joinList(list types.List) *string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	items := []string{}
+	for _, item := range list.Elements() {
+		items = append(items, item.(types.String).ValueString())
+	}
+	value := strings.Join(items, ",")
+
+	return &value
+}
+
+// splitList parses a proxmox comma separated list.
+func splitList(value string) types.List {
+	if value == "" {
+		return types.ListNull(types.StringType)
+	}
+
+	items := []attr.Value{}
+	for _, item := range strings.Split(value, ",") {
+		items = append(items, types.StringValue(strings.TrimSpace(item)))
+	}
+
+	return types.ListValueMust(types.StringType, items)
+}
+
+// deleted returns the names of the params whose planned
+// value is null while the current one is not.
+func deleted(params map[string][2]attr.Value) []string {
+	names := []string{}
+	for name, values := range params {
+		if values[0].IsNull() && !values[1].IsNull() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
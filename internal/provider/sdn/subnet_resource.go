@@ -0,0 +1,400 @@
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SubnetResource{}
+var _ resource.ResourceWithImportState = &SubnetResource{}
+var _ resource.ResourceWithModifyPlan = &SubnetResource{}
+
+// errSubnetNotFound is returned when the vnet has no subnet
+// matching the resource.
+var errSubnetNotFound = errors.New("subnet not found")
+
+func NewSubnetResource() resource.Resource {
+	return &SubnetResource{}
+}
+
+// SubnetResource defines the resource implementation.
+type SubnetResource struct {
+	client *proxmox.Client
+}
+
+// SubnetResourceModel describes the resource data model.
+type SubnetResourceModel struct {
+	Cluster       types.String     `tfsdk:"cluster"`
+	ID            types.String     `tfsdk:"id"`
+	Vnet          types.String     `tfsdk:"vnet"`
+	CIDR          types.String     `tfsdk:"cidr"`
+	Gateway       types.String     `tfsdk:"gateway"`
+	SNAT          types.Bool       `tfsdk:"snat"`
+	DHCPDNSServer types.String     `tfsdk:"dhcp_dns_server"`
+	DHCPRanges    []dhcpRangeModel `tfsdk:"dhcp_ranges"`
+}
+
+// dhcpRangeModel maps the dhcp_ranges schema data.
+type dhcpRangeModel struct {
+	Start types.String `tfsdk:"start"`
+	End   types.String `tfsdk:"end"`
+}
+
+func (r *SubnetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "sdn_subnet"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *SubnetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SDN subnet resource",
+		Description:         DESC_SUBNET,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_SUBNET_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vnet": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_SUBNET_VNET,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_SUBNET_CIDR,
+				Validators: []validator.String{
+					validators.CIDR(0),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_SUBNET_GATEWAY,
+				Validators: []validator.String{
+					validators.IPAddress(0),
+				},
+			},
+			"snat": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_SUBNET_SNAT),
+				Description: DESC_SUBNET_SNAT,
+			},
+			"dhcp_dns_server": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_SUBNET_DHCP_DNS_SERVER,
+				Validators: []validator.String{
+					validators.IPAddress(0),
+				},
+			},
+			"dhcp_ranges": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: DESC_SUBNET_DHCP_RANGES,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{
+							Required:    true,
+							Description: DESC_SUBNET_DHCP_RANGE_START,
+							Validators: []validator.String{
+								validators.IPAddress(0),
+							},
+						},
+						"end": schema.StringAttribute{
+							Required:    true,
+							Description: DESC_SUBNET_DHCP_RANGE_END,
+							Validators: []validator.String{
+								validators.IPAddress(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *SubnetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *SubnetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	// the pending sdn changes are applied cluster wide.
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	preflight.Require(client, &resp.Diagnostics, "SDN.Allocate", "/sdn")
+}
+
+func (r *SubnetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SubnetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest()
+	apiReq.Subnet = data.CIDR.ValueString()
+
+	err := apply(ctx, client, func() error {
+		return client.CreateSDNSubnet(ctx, apiReq)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create sdn subnet, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn subnet, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SubnetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SubnetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) || errors.Is(err, errSubnetNotFound) {
+		tflog.Warn(ctx, "sdn subnet not found, removing it from state", map[string]any{"cidr": data.CIDR.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn subnet, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SubnetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SubnetResourceModel
+	var state SubnetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest()
+	apiReq.Subnet = state.ID.ValueString()
+	apiReq.Delete = deleted(map[string][2]attr.Value{
+		"gateway":         {data.Gateway, state.Gateway},
+		"dhcp-dns-server": {data.DHCPDNSServer, state.DHCPDNSServer},
+	})
+	if len(data.DHCPRanges) == 0 && len(state.DHCPRanges) > 0 {
+		apiReq.Delete = append(apiReq.Delete, "dhcp-range")
+	}
+
+	err := apply(ctx, client, func() error {
+		return client.UpdateSDNSubnet(ctx, apiReq)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sdn subnet, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn subnet, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SubnetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SubnetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := apply(ctx, client, func() error {
+		err := client.DeleteSDNSubnet(ctx, data.Vnet.ValueString(), data.ID.ValueString())
+		if proxmox.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete sdn subnet, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// ImportState imports a subnet using the "vnet/cidr" format,
+// i.e. "vnet1/10.0.0.0/24".
+func (r *SubnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	cluster, id := clusterattr.ImportID(req.ID)
+	vnet, cidr, found := strings.Cut(id, "/")
+	if _, err := netip.ParsePrefix(cidr); !found || vnet == "" || err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: [cluster:]vnet/cidr. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(clusterattr.Name), cluster)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vnet"), vnet)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cidr"), cidr)...)
+}
+
+// read loads the remote subnet config into data. The subnet is
+// looked up by id, or by cidr when the id is not known yet.
+func (r *SubnetResource) read(ctx context.Context, client *proxmox.Client, data *SubnetResourceModel) error {
+	subnets, err := client.GetSDNSubnets(ctx, data.Vnet.ValueString())
+	if err != nil {
+		return err
+	}
+
+	var remote *proxmox.SDNSubnet
+	for i, subnet := range subnets {
+		if subnet.Subnet == data.ID.ValueString() || sameCIDR(subnet.CIDR, data.CIDR.ValueString()) {
+			remote = &subnets[i]
+			break
+		}
+	}
+	if remote == nil {
+		return errSubnetNotFound
+	}
+
+	data.ID = types.StringValue(remote.Subnet)
+	if !sameCIDR(remote.CIDR, data.CIDR.ValueString()) {
+		data.CIDR = types.StringValue(remote.CIDR)
+	}
+	data.Gateway = optional.String(remote.Gateway)
+	data.SNAT = types.BoolValue(remote.SNAT == 1)
+	data.DHCPDNSServer = optional.String(remote.DHCPDNSServer)
+	data.DHCPRanges = nil
+	for _, r := range remote.DHCPRange {
+		data.DHCPRanges = append(data.DHCPRanges, dhcpRangeModel{
+			Start: types.StringValue(r.StartAddress),
+			End:   types.StringValue(r.EndAddress),
+		})
+	}
+
+	return nil
+}
+
+func (m SubnetResourceModel) toRequest() proxmox.SDNSubnetRequest {
+	req := proxmox.SDNSubnetRequest{
+		Vnet:          m.Vnet.ValueString(),
+		Gateway:       m.Gateway.ValueStringPointer(),
+		SNAT:          m.SNAT.ValueBoolPointer(),
+		DHCPDNSServer: m.DHCPDNSServer.ValueStringPointer(),
+	}
+
+	for _, r := range m.DHCPRanges {
+		req.DHCPRange = append(req.DHCPRange, proxmox.SDNDHCPRange{
+			StartAddress: r.Start.ValueString(),
+			EndAddress:   r.End.ValueString(),
+		})
+	}
+
+	return req
+}
+
+// sameCIDR reports whether both values are the same network,
+// regardless of the host bits.
+func sameCIDR(a, b string) bool {
+	pa, err := netip.ParsePrefix(a)
+	if err != nil {
+		return false
+	}
+	pb, err := netip.ParsePrefix(b)
+	if err != nil {
+		return false
+	}
+	return pa.Masked() == pb.Masked()
+}
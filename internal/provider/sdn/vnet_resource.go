@@ -0,0 +1,306 @@
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VnetResource{}
+var _ resource.ResourceWithImportState = &VnetResource{}
+var _ resource.ResourceWithModifyPlan = &VnetResource{}
+
+func NewVnetResource() resource.Resource {
+	return &VnetResource{}
+}
+
+// VnetResource defines the resource implementation.
+type VnetResource struct {
+	client *proxmox.Client
+}
+
+// VnetResourceModel describes the resource data model.
+type VnetResourceModel struct {
+	Cluster      types.String `tfsdk:"cluster"`
+	ID           types.String `tfsdk:"id"`
+	Vnet         types.String `tfsdk:"vnet"`
+	Zone         types.String `tfsdk:"zone"`
+	Alias        types.String `tfsdk:"alias"`
+	Tag          types.Int64  `tfsdk:"tag"`
+	VlanAware    types.Bool   `tfsdk:"vlan_aware"`
+	IsolatePorts types.Bool   `tfsdk:"isolate_ports"`
+}
+
+func (r *VnetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "sdn_vnet"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *VnetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SDN vnet resource",
+		Description:         DESC_VNET,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_VNET_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vnet": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_VNET_VNET,
+				Validators: []validator.String{
+					validators.Regex(sdnIDRegex, "value must be up to 8 lowercase letters and digits, starting with a letter"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_VNET_ZONE,
+			},
+			"alias": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_VNET_ALIAS,
+			},
+			"tag": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_VNET_TAG,
+				Validators: []validator.Int64{
+					validators.Between(1, 16777215),
+				},
+			},
+			"vlan_aware": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_VNET_VLAN_AWARE),
+				Description: DESC_VNET_VLAN_AWARE,
+			},
+			"isolate_ports": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_VNET_ISOLATE_PORTS),
+				Description: DESC_VNET_ISOLATE_PORTS,
+			},
+		},
+	}
+}
+
+func (r *VnetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *VnetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	// the pending sdn changes are applied cluster wide.
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	preflight.Require(client, &resp.Diagnostics, "SDN.Allocate", "/sdn")
+}
+
+func (r *VnetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VnetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := apply(ctx, client, func() error {
+		return client.CreateSDNVnet(ctx, data.toRequest())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create sdn vnet, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn vnet, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VnetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VnetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "sdn vnet not found, removing it from state", map[string]any{"vnet": data.Vnet.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn vnet, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VnetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VnetResourceModel
+	var state VnetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest()
+	apiReq.Delete = deleted(map[string][2]attr.Value{
+		"alias": {data.Alias, state.Alias},
+		"tag":   {data.Tag, state.Tag},
+	})
+
+	err := apply(ctx, client, func() error {
+		return client.UpdateSDNVnet(ctx, apiReq)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sdn vnet, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn vnet, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VnetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VnetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := apply(ctx, client, func() error {
+		err := client.DeleteSDNVnet(ctx, data.Vnet.ValueString())
+		if proxmox.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete sdn vnet, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (r *VnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	cluster, id := clusterattr.ImportID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(clusterattr.Name), cluster)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vnet"), id)...)
+}
+
+// read loads the remote vnet config into data.
+func (r *VnetResource) read(ctx context.Context, client *proxmox.Client, data *VnetResourceModel) error {
+	remote, err := client.GetSDNVnet(ctx, data.Vnet.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.ID = types.StringValue(remote.Vnet)
+	data.Vnet = types.StringValue(remote.Vnet)
+	data.Zone = types.StringValue(remote.Zone)
+	data.Alias = optional.String(remote.Alias)
+	data.Tag = optional.Int64(remote.Tag)
+	data.VlanAware = types.BoolValue(remote.VlanAware == 1)
+	data.IsolatePorts = types.BoolValue(remote.IsolatePorts == 1)
+
+	return nil
+}
+
+func (m VnetResourceModel) toRequest() proxmox.SDNVnetRequest {
+	return proxmox.SDNVnetRequest{
+		Vnet:         m.Vnet.ValueString(),
+		Zone:         m.Zone.ValueString(),
+		Alias:        m.Alias.ValueStringPointer(),
+		Tag:          m.Tag.ValueInt64Pointer(),
+		VlanAware:    m.VlanAware.ValueBoolPointer(),
+		IsolatePorts: m.IsolatePorts.ValueBoolPointer(),
+	}
+}
@@ -0,0 +1,470 @@
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneResource{}
+var _ resource.ResourceWithImportState = &ZoneResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneResource{}
+var _ resource.ResourceWithValidateConfig = &ZoneResource{}
+
+func NewZoneResource() resource.Resource {
+	return &ZoneResource{}
+}
+
+// sdnIDRegex matches the zone and vnet ids.
+var sdnIDRegex = regexp.MustCompile(`^[a-z][a-z0-9]{0,7}$`)
+
+// zoneTypeAttributes lists the zone types supported by the
+// type specific attributes.
+var zoneTypeAttributes = map[string][]string{
+	"dhcp":          {"simple"},
+	"bridge":        {"vlan", "qinq"},
+	"tag":           {"qinq"},
+	"vlan_protocol": {"qinq"},
+	"peers":         {"vxlan"},
+	"controller":    {"evpn"},
+	"vrf_vxlan":     {"evpn"},
+	"exit_nodes":    {"evpn"},
+	"mac":           {"evpn"},
+}
+
+// zoneTypeRequired lists the attributes required by each
+// zone type.
+var zoneTypeRequired = map[string][]string{
+	"vlan":  {"bridge"},
+	"qinq":  {"bridge", "tag"},
+	"vxlan": {"peers"},
+	"evpn":  {"controller", "vrf_vxlan"},
+}
+
+// ZoneResource defines the resource implementation.
+type ZoneResource struct {
+	client *proxmox.Client
+}
+
+// ZoneResourceModel describes the resource data model.
+type ZoneResourceModel struct {
+	Cluster      types.String `tfsdk:"cluster"`
+	ID           types.String `tfsdk:"id"`
+	Zone         types.String `tfsdk:"zone"`
+	Type         types.String `tfsdk:"type"`
+	Nodes        types.List   `tfsdk:"nodes"`
+	MTU          types.Int64  `tfsdk:"mtu"`
+	IPAM         types.String `tfsdk:"ipam"`
+	DHCP         types.String `tfsdk:"dhcp"`
+	Bridge       types.String `tfsdk:"bridge"`
+	Tag          types.Int64  `tfsdk:"tag"`
+	VlanProtocol types.String `tfsdk:"vlan_protocol"`
+	Peers        types.List   `tfsdk:"peers"`
+	Controller   types.String `tfsdk:"controller"`
+	VrfVxlan     types.Int64  `tfsdk:"vrf_vxlan"`
+	ExitNodes    types.List   `tfsdk:"exit_nodes"`
+	MAC          types.String `tfsdk:"mac"`
+}
+
+func (m ZoneResourceModel) values() map[string]attr.Value {
+	return map[string]attr.Value{
+		"dhcp":          m.DHCP,
+		"bridge":        m.Bridge,
+		"tag":           m.Tag,
+		"vlan_protocol": m.VlanProtocol,
+		"peers":         m.Peers,
+		"controller":    m.Controller,
+		"vrf_vxlan":     m.VrfVxlan,
+		"exit_nodes":    m.ExitNodes,
+		"mac":           m.MAC,
+	}
+}
+
+func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "sdn_zone"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SDN zone resource",
+		Description:         DESC_ZONE,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_ZONE_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_ZONE_ZONE,
+				Validators: []validator.String{
+					validators.Regex(sdnIDRegex, "value must be up to 8 lowercase letters and digits, starting with a letter"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_ZONE_TYPE,
+				Validators: []validator.String{
+					validators.OneOf("simple", "vlan", "qinq", "vxlan", "evpn"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nodes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: DESC_ZONE_NODES,
+			},
+			"mtu": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_ZONE_MTU,
+				Validators: []validator.Int64{
+					validators.Between(1280, 65520),
+				},
+			},
+			"ipam": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_IPAM,
+			},
+			"dhcp": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_DHCP,
+				Validators: []validator.String{
+					validators.OneOf("dnsmasq"),
+				},
+			},
+			"bridge": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_BRIDGE,
+			},
+			"tag": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_ZONE_TAG,
+				Validators: []validator.Int64{
+					validators.Between(1, 4094),
+				},
+			},
+			"vlan_protocol": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_VLAN_PROTOCOL,
+				Validators: []validator.String{
+					validators.OneOf("802.1q", "802.1ad"),
+				},
+			},
+			"peers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: DESC_ZONE_PEERS,
+			},
+			"controller": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_CONTROLLER,
+			},
+			"vrf_vxlan": schema.Int64Attribute{
+				Optional:    true,
+				Description: DESC_ZONE_VRF_VXLAN,
+				Validators: []validator.Int64{
+					validators.Between(1, 16777215),
+				},
+			},
+			"exit_nodes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: DESC_ZONE_EXIT_NODES,
+			},
+			"mac": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_ZONE_MAC,
+			},
+		},
+	}
+}
+
+func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig ensures the type specific attributes are only
+// used along with the zone types supporting them.
+func (r *ZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+	typ := data.Type.ValueString()
+	values := data.values()
+
+	for name, supported := range zoneTypeAttributes {
+		if values[name].IsNull() || slices.Contains(supported, typ) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Unsupported SDN Zone Attribute",
+			fmt.Sprintf("Attribute %s can only be used with the %s zone types, got: %q.",
+				name, strings.Join(supported, ", "), typ),
+		)
+	}
+
+	for _, name := range zoneTypeRequired[typ] {
+		if !values[name].IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Missing SDN Zone Attribute",
+			fmt.Sprintf("Attribute %s is required by the %s zone type.", name, typ),
+		)
+	}
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	// the pending sdn changes are applied cluster wide.
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	preflight.Require(client, &resp.Diagnostics, "SDN.Allocate", "/sdn")
+}
+
+func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := apply(ctx, client, func() error {
+		return client.CreateSDNZone(ctx, data.toRequest())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create sdn zone, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn zone, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "sdn zone not found, removing it from state", map[string]any{"zone": data.Zone.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn zone, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneResourceModel
+	var state ZoneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest()
+	apiReq.Delete = deleted(map[string][2]attr.Value{
+		"nodes":         {data.Nodes, state.Nodes},
+		"mtu":           {data.MTU, state.MTU},
+		"ipam":          {data.IPAM, state.IPAM},
+		"dhcp":          {data.DHCP, state.DHCP},
+		"bridge":        {data.Bridge, state.Bridge},
+		"tag":           {data.Tag, state.Tag},
+		"vlan-protocol": {data.VlanProtocol, state.VlanProtocol},
+		"peers":         {data.Peers, state.Peers},
+		"controller":    {data.Controller, state.Controller},
+		"vrf-vxlan":     {data.VrfVxlan, state.VrfVxlan},
+		"exitnodes":     {data.ExitNodes, state.ExitNodes},
+		"mac":           {data.MAC, state.MAC},
+	})
+
+	err := apply(ctx, client, func() error {
+		return client.UpdateSDNZone(ctx, apiReq)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sdn zone, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sdn zone, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := apply(ctx, client, func() error {
+		err := client.DeleteSDNZone(ctx, data.Zone.ValueString())
+		if proxmox.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete sdn zone, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	cluster, id := clusterattr.ImportID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(clusterattr.Name), cluster)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), id)...)
+}
+
+// read loads the remote zone config into data.
+func (r *ZoneResource) read(ctx context.Context, client *proxmox.Client, data *ZoneResourceModel) error {
+	remote, err := client.GetSDNZone(ctx, data.Zone.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.ID = types.StringValue(remote.Zone)
+	data.Zone = types.StringValue(remote.Zone)
+	data.Type = types.StringValue(remote.Type)
+	data.Nodes = splitList(remote.Nodes)
+	data.MTU = optional.Int64(remote.MTU)
+	data.IPAM = optional.String(remote.IPAM)
+	data.DHCP = optional.String(remote.DHCP)
+	data.Bridge = optional.String(remote.Bridge)
+	data.Tag = optional.Int64(remote.Tag)
+	data.VlanProtocol = optional.String(remote.VlanProtocol)
+	data.Peers = splitList(remote.Peers)
+	data.Controller = optional.String(remote.Controller)
+	data.VrfVxlan = optional.Int64(remote.VrfVxlan)
+	data.ExitNodes = splitList(remote.ExitNodes)
+	data.MAC = optional.String(remote.MAC)
+
+	return nil
+}
+
+func (m ZoneResourceModel) toRequest() proxmox.SDNZoneRequest {
+	return proxmox.SDNZoneRequest{
+		Zone:         m.Zone.ValueString(),
+		Type:         m.Type.ValueString(),
+		Nodes:        joinList(m.Nodes),
+		MTU:          m.MTU.ValueInt64Pointer(),
+		IPAM:         m.IPAM.ValueStringPointer(),
+		DHCP:         m.DHCP.ValueStringPointer(),
+		Bridge:       m.Bridge.ValueStringPointer(),
+		Tag:          m.Tag.ValueInt64Pointer(),
+		VlanProtocol: m.VlanProtocol.ValueStringPointer(),
+		Peers:        joinList(m.Peers),
+		Controller:   m.Controller.ValueStringPointer(),
+		VrfVxlan:     m.VrfVxlan.ValueInt64Pointer(),
+		ExitNodes:    joinList(m.ExitNodes),
+		MAC:          m.MAC.ValueStringPointer(),
+	}
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &contentDataSource{}
+	_ datasource.DataSourceWithConfigure = &contentDataSource{}
+)
+
+// NewContentDataSource is a helper function to simplify the provider implementation.
+func NewContentDataSource() datasource.DataSource {
+	return &contentDataSource{}
+}
+
+// contentDataSource is the data source implementation.
+type contentDataSource struct {
+	client *proxmox.Client
+}
+
+// contentDataSourceModel describes the data source data model.
+type contentDataSourceModel struct {
+	Cluster     types.String  `tfsdk:"cluster"`
+	Node        types.String  `tfsdk:"node"`
+	Storage     types.String  `tfsdk:"storage"`
+	ContentType types.String  `tfsdk:"content_type"`
+	NameRegex   types.String  `tfsdk:"name_regex"`
+	Volumes     []volumeModel `tfsdk:"volumes"`
+}
+
+// volumeModel describes a storage volume.
+type volumeModel struct {
+	VolID     types.String `tfsdk:"volid"`
+	Name      types.String `tfsdk:"name"`
+	Content   types.String `tfsdk:"content"`
+	Format    types.String `tfsdk:"format"`
+	Size      types.Int64  `tfsdk:"size"`
+	CTime     types.Int64  `tfsdk:"ctime"`
+	Notes     types.String `tfsdk:"notes"`
+	Protected types.Bool   `tfsdk:"protected"`
+	VMID      types.Int64  `tfsdk:"vmid"`
+}
+
+// Metadata returns the data source type name.
+func (d *contentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	name := "storage_content"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (d *contentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	volumeSchema := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"volid":     schema.StringAttribute{Computed: true, Description: DESC_CONTENT_VOLID},
+			"name":      schema.StringAttribute{Computed: true, Description: DESC_CONTENT_NAME},
+			"content":   schema.StringAttribute{Computed: true, Description: DESC_CONTENT_CONTENT},
+			"format":    schema.StringAttribute{Computed: true, Description: DESC_CONTENT_FORMAT},
+			"size":      schema.Int64Attribute{Computed: true, Description: DESC_CONTENT_SIZE},
+			"ctime":     schema.Int64Attribute{Computed: true, Description: DESC_CONTENT_CTIME},
+			"notes":     schema.StringAttribute{Computed: true, Description: DESC_CONTENT_NOTES},
+			"protected": schema.BoolAttribute{Computed: true, Description: DESC_CONTENT_PROTECTED},
+			"vmid":      schema.Int64Attribute{Computed: true, Description: DESC_CONTENT_VMID},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: DESC_CONTENT,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.DataSourceAttribute(),
+			"node": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_CONTENT_NODE,
+			},
+			"storage": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_CONTENT_STORAGE,
+			},
+			"content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_CONTENT_CONTENT_TYPE,
+				Validators: []validator.String{
+					validators.OneOf(storageContentTypes...),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_CONTENT_NAME_REGEX,
+			},
+			"volumes": schema.ListNestedAttribute{
+				NestedObject: volumeSchema,
+				Computed:     true,
+				Description:  DESC_CONTENT_VOLUMES,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *contentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state contentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, d.client, state.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		re, err := regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+			return
+		}
+		nameRegex = re
+	}
+
+	tflog.Info(ctx, "reading storage content", map[string]any{
+		"node":    state.Node.ValueString(),
+		"storage": state.Storage.ValueString(),
+	})
+
+	contents, err := client.GetStorageContents(ctx, state.Node.ValueString(), state.Storage.ValueString(), state.ContentType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Proxmox Storage Content",
+			err.Error(),
+		)
+		return
+	}
+
+	// newest first, the volid keeps the order stable.
+	sort.SliceStable(contents, func(i, j int) bool {
+		if contents[i].CTime != contents[j].CTime {
+			return contents[i].CTime > contents[j].CTime
+		}
+		return contents[i].VolID < contents[j].VolID
+	})
+
+	state.Volumes = []volumeModel{}
+	for _, c := range contents {
+		name := volumeName(c.VolID)
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+
+		volume := volumeModel{
+			VolID:     types.StringValue(c.VolID),
+			Name:      types.StringValue(name),
+			Content:   types.StringValue(c.Content),
+			Format:    types.StringValue(c.Format),
+			Size:      types.Int64Value(c.Size),
+			CTime:     types.Int64Value(c.CTime),
+			Notes:     types.StringNull(),
+			Protected: types.BoolValue(c.Protected == 1),
+			VMID:      types.Int64Null(),
+		}
+		if c.Notes != "" {
+			volume.Notes = types.StringValue(c.Notes)
+		}
+		if c.VMID != 0 {
+			volume.VMID = types.Int64Value(int64(c.VMID))
+		}
+
+		state.Volumes = append(state.Volumes, volume)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *contentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// volumeName strips the storage and content prefixes from a
+// volid, i.e. "local:vztmpl/debian.tar.zst" is "debian.tar.zst".
+func volumeName(volid string) string {
+	_, name, _ := strings.Cut(volid, ":")
+	if _, file, ok := strings.Cut(name, "/"); ok {
+		return file
+	}
+	return name
+}
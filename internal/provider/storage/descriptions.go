@@ -0,0 +1,114 @@
+package storage
+
+// descriptions for storage
+const (
+	DESC_STORAGE = "Storage definition (datacenter storage " +
+		"configuration).\n" +
+		"Note: destroying this resource removes the storage " +
+		"definition only, the stored data is left untouched."
+	DESC_STORAGE_ID      = "The storage id."
+	DESC_STORAGE_STORAGE = "The storage name."
+	DESC_STORAGE_TYPE    = "Storage type.\n" +
+		"Values: dir | lvmthin | zfspool | nfs | cifs | pbs | cephfs"
+	DESC_STORAGE_CONTENT = "Allowed content types. Proxmox " +
+		"assigns the storage type defaults when not set.\n" +
+		"Values: images | rootdir | vztmpl | iso | backup | snippets | import"
+	DESC_STORAGE_NODES = "Nodes the storage is available on. " +
+		"All nodes when not set."
+	DESC_STORAGE_SHARED = "Flag the storage as shared, meaning " +
+		"its content is the same on every node (i.e. a " +
+		"mounted directory). Network storages are always shared."
+	DESC_STORAGE_DISABLE       = "Flag to disable the storage."
+	DESC_STORAGE_PRUNE_BACKUPS = "Backup retention options. " +
+		"Backups are kept forever when not set."
+	DESC_STORAGE_PRUNE_KEEP_ALL     = "Keep all backups, conflicts with the other options."
+	DESC_STORAGE_PRUNE_KEEP_LAST    = "Keep the last <n> backups."
+	DESC_STORAGE_PRUNE_KEEP_HOURLY  = "Keep backups for the last <n> different hours."
+	DESC_STORAGE_PRUNE_KEEP_DAILY   = "Keep backups for the last <n> different days."
+	DESC_STORAGE_PRUNE_KEEP_WEEKLY  = "Keep backups for the last <n> different weeks."
+	DESC_STORAGE_PRUNE_KEEP_MONTHLY = "Keep backups for the last <n> different months."
+	DESC_STORAGE_PRUNE_KEEP_YEARLY  = "Keep backups for the last <n> different years."
+	DESC_STORAGE_PATH               = "File system path (dir)."
+	DESC_STORAGE_VG_NAME            = "LVM volume group name (lvmthin)."
+	DESC_STORAGE_THIN_POOL          = "LVM thin pool name (lvmthin)."
+	DESC_STORAGE_POOL               = "ZFS pool name (zfspool)."
+	DESC_STORAGE_SPARSE             = "Use ZFS thin provisioning (zfspool)."
+	DESC_STORAGE_SERVER             = "Server address (nfs, cifs, pbs)."
+	DESC_STORAGE_EXPORT             = "NFS export path (nfs)."
+	DESC_STORAGE_OPTIONS            = "NFS mount options (nfs)."
+	DESC_STORAGE_SHARE              = "CIFS share name (cifs)."
+	DESC_STORAGE_DOMAIN             = "CIFS domain (cifs)."
+	DESC_STORAGE_SMB_VERSION        = "SMB protocol version (cifs).\n" +
+		"Values: default | 2.0 | 2.1 | 3 | 3.0 | 3.11"
+	DESC_STORAGE_SUBDIR    = "Subdirectory to mount (cifs, cephfs)."
+	DESC_STORAGE_USERNAME  = "Username (cifs, pbs, cephfs)."
+	DESC_STORAGE_PASSWORD  = "Password (cifs, pbs)."
+	DESC_STORAGE_DATASTORE = "Proxmox backup server datastore " +
+		"name (pbs)."
+	DESC_STORAGE_NAMESPACE   = "Proxmox backup server namespace (pbs)."
+	DESC_STORAGE_FINGERPRINT = "Proxmox backup server certificate " +
+		"sha256 fingerprint (pbs)."
+	DESC_STORAGE_ENCRYPTION_KEY = "Client side encryption key " +
+		"(pbs). Proxmox does not return it, so changes made " +
+		"outside terraform are not detected."
+	DESC_STORAGE_MONHOST = "Monitor addresses of an external " +
+		"ceph cluster (cephfs)."
+	DESC_STORAGE_KEYRING = "Client keyring of an external " +
+		"ceph cluster (cephfs). Proxmox does not return it, so " +
+		"changes made outside terraform are not detected."
+	DESC_STORAGE_FS_NAME = "The ceph file system name (cephfs)."
+)
+
+// descriptions for storage files
+const (
+	DESC_FILE = "File uploaded into a node storage, either from " +
+		"a local file or from inline content. The upload is " +
+		"replaced when the source checksum changes or when the " +
+		"remote file size no longer matches the uploaded one.\n" +
+		"Note: snippets uploads require a proxmox version whose " +
+		"upload api accepts the snippets content."
+	DESC_FILE_ID           = "The file volume ID."
+	DESC_FILE_NODE         = "The node the file is uploaded to."
+	DESC_FILE_STORAGE      = "The storage the file is uploaded into."
+	DESC_FILE_CONTENT_TYPE = "The storage content type of the file.\n" +
+		"Values: iso | vztmpl | snippets | import"
+	DESC_FILE_SOURCE_FILE = "Path of the local file to upload. " +
+		"Conflicts with source_content."
+	DESC_FILE_SOURCE_CONTENT = "Inline content to upload, i.e. a " +
+		"cloud-init snippet or a hookscript. Conflicts with source_file."
+	DESC_FILE_FILE_NAME = "The remote file name. Defaults to the " +
+		"source_file base name, required with source_content."
+	DESC_FILE_CHECKSUM = "The source sha256 checksum, verified by " +
+		"proxmox on upload."
+	DESC_FILE_VOLID = "The file volume ID, i.e. local:snippets/hook.sh."
+	DESC_FILE_SIZE  = "The file size in bytes."
+)
+
+// descriptions for storage content
+const (
+	DESC_CONTENT = "Volumes of a node storage, sorted from the " +
+		"newest to the oldest, so the first volume is the latest " +
+		"matching one."
+	DESC_CONTENT_NODE         = "The node name."
+	DESC_CONTENT_STORAGE      = "The storage name."
+	DESC_CONTENT_CONTENT_TYPE = "Only list the volumes of this content type.\n" +
+		"Values: images | rootdir | vztmpl | iso | backup | snippets | import"
+	DESC_CONTENT_NAME_REGEX = "Only list the volumes whose name " +
+		"(the volid without the storage and content prefix) matches " +
+		"this regular expression."
+	DESC_CONTENT_VOLUMES   = "The matching volumes."
+	DESC_CONTENT_VOLID     = "The volume ID."
+	DESC_CONTENT_NAME      = "The volume name."
+	DESC_CONTENT_CONTENT   = "The volume content type."
+	DESC_CONTENT_FORMAT    = "The volume format, i.e. iso, tzst or raw."
+	DESC_CONTENT_SIZE      = "The volume size in bytes."
+	DESC_CONTENT_CTIME     = "The volume creation time (unix epoch)."
+	DESC_CONTENT_NOTES     = "The volume notes, only set on backups."
+	DESC_CONTENT_PROTECTED = "Whether the volume is protected from removal."
+	DESC_CONTENT_VMID      = "The guest the volume belongs to, if any."
+)
+
+// default values for storage
+const (
+	DFLT_STORAGE_DISABLE = false
+)
@@ -0,0 +1,439 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FileResource{}
+var _ resource.ResourceWithModifyPlan = &FileResource{}
+var _ resource.ResourceWithValidateConfig = &FileResource{}
+
+// fileNameRegex matches file names without any directory.
+var fileNameRegex = regexp.MustCompile(`^[^/\\]+$`)
+
+func NewFileResource() resource.Resource {
+	return &FileResource{}
+}
+
+// FileResource defines the resource implementation.
+type FileResource struct {
+	client *proxmox.Client
+}
+
+// FileResourceModel describes the resource data model.
+type FileResourceModel struct {
+	Cluster       types.String `tfsdk:"cluster"`
+	ID            types.String `tfsdk:"id"`
+	Node          types.String `tfsdk:"node"`
+	Storage       types.String `tfsdk:"storage"`
+	ContentType   types.String `tfsdk:"content_type"`
+	SourceFile    types.String `tfsdk:"source_file"`
+	SourceContent types.String `tfsdk:"source_content"`
+	FileName      types.String `tfsdk:"file_name"`
+	Checksum      types.String `tfsdk:"checksum"`
+	VolID         types.String `tfsdk:"volid"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+func (r *FileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "storage_file"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	fixed := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Storage file resource",
+		Description:         DESC_FILE,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_FILE_ID,
+			},
+			"node": schema.StringAttribute{
+				Required:      true,
+				Description:   DESC_FILE_NODE,
+				PlanModifiers: fixed,
+			},
+			"storage": schema.StringAttribute{
+				Required:      true,
+				Description:   DESC_FILE_STORAGE,
+				PlanModifiers: fixed,
+			},
+			"content_type": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_FILE_CONTENT_TYPE,
+				Validators: []validator.String{
+					validators.OneOf("iso", "vztmpl", "snippets", "import"),
+				},
+				PlanModifiers: fixed,
+			},
+			"source_file": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_FILE_SOURCE_FILE,
+				PlanModifiers: fixed,
+			},
+			"source_content": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_FILE_SOURCE_CONTENT,
+				PlanModifiers: fixed,
+			},
+			"file_name": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_FILE_FILE_NAME,
+				Validators: []validator.String{
+					validators.Regex(fileNameRegex, "value must be a file name, without any path"),
+				},
+				PlanModifiers: fixed,
+			},
+			"checksum": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_FILE_CHECKSUM,
+			},
+			"volid": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_FILE_VOLID,
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: DESC_FILE_SIZE,
+			},
+		},
+	}
+}
+
+func (r *FileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig ensures exactly one source is set and that the
+// inline content comes along with a file name.
+func (r *FileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.SourceFile.IsUnknown() && !data.SourceContent.IsUnknown() && data.SourceFile.IsNull() == data.SourceContent.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_file"),
+			"Invalid File Source",
+			"Exactly one of the source_file or source_content attributes must be set.",
+		)
+	}
+
+	if !data.SourceContent.IsNull() && data.FileName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_name"),
+			"Missing File Name",
+			"Attribute file_name is required along with source_content.",
+		)
+	}
+}
+
+// ModifyPlan computes the source checksum, so that changes of the
+// local file replace the upload, along with the remote volume ID,
+// and reports the privileges missing to upload the file.
+func (r *FileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data FileResourceModel
+	var config FileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.FileName = config.FileName
+	if config.FileName.IsNull() {
+		data.FileName = types.StringUnknown()
+		if !data.SourceFile.IsNull() && !data.SourceFile.IsUnknown() {
+			data.FileName = types.StringValue(filepath.Base(data.SourceFile.ValueString()))
+		}
+	}
+
+	data.VolID = types.StringUnknown()
+	if !data.Storage.IsUnknown() && !data.ContentType.IsUnknown() && !data.FileName.IsUnknown() {
+		data.VolID = types.StringValue(fmt.Sprintf("%s:%s/%s",
+			data.Storage.ValueString(), data.ContentType.ValueString(), data.FileName.ValueString()))
+	}
+	data.ID = data.VolID
+
+	data.Checksum = types.StringUnknown()
+	if !data.SourceFile.IsUnknown() && !data.SourceContent.IsUnknown() {
+		checksum, err := data.sourceChecksum()
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// the file might be generated during the apply.
+			tflog.Warn(ctx, "source file not found, checksum unknown until apply", map[string]any{"source_file": data.SourceFile.ValueString()})
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Invalid Source File", err.Error())
+			return
+		default:
+			data.Checksum = types.StringValue(checksum)
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state FileResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !data.Checksum.Equal(state.Checksum) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("checksum"))
+			data.Size = types.Int64Unknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+
+	if preflight.Creates(req) && !data.Storage.IsUnknown() {
+		client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+		if !ok {
+			return
+		}
+		preflight.Require(client, &resp.Diagnostics, "Datastore.AllocateTemplate", "/storage/"+data.Storage.ValueString())
+	}
+}
+
+func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, size, err := data.openSource()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Source File", err.Error())
+		return
+	}
+	defer source.Close()
+
+	// the checksum is computed again as the source might not be
+	// known at plan time.
+	checksum, err := data.sourceChecksum()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Source File", err.Error())
+		return
+	}
+	algorithm := "sha256"
+
+	fileName := data.FileName.ValueString()
+	if data.FileName.IsUnknown() {
+		fileName = filepath.Base(data.SourceFile.ValueString())
+	}
+
+	err = client.UploadStorageFile(ctx, proxmox.UploadRequest{
+		Node:              data.Node.ValueString(),
+		Storage:           data.Storage.ValueString(),
+		Content:           data.ContentType.ValueString(),
+		Checksum:          &checksum,
+		ChecksumAlgorithm: &algorithm,
+	}, fileName, source, size)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload file %s, got error: %s", fileName, err))
+		return
+	}
+
+	data.FileName = types.StringValue(fileName)
+	data.Checksum = types.StringValue(checksum)
+	data.VolID = types.StringValue(fmt.Sprintf("%s:%s/%s", data.Storage.ValueString(), data.ContentType.ValueString(), fileName))
+	data.ID = data.VolID
+
+	volume, err := client.GetStorageVolume(ctx, data.Node.ValueString(), data.Storage.ValueString(), data.VolID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file, got error: %s", err))
+		return
+	}
+	data.Size = types.Int64Value(volume.Size)
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := client.GetStorageVolume(ctx, data.Node.ValueString(), data.Storage.ValueString(), data.VolID.ValueString())
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "file not found, removing it from state", map[string]any{"volid": data.VolID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file, got error: %s", err))
+		return
+	}
+
+	// proxmox does not expose the volume checksums, a size change
+	// means the file was modified outside terraform. The checksum
+	// is cleared so that the next plan replaces the upload.
+	if volume.Size != data.Size.ValueInt64() {
+		tflog.Warn(ctx, "file size changed, it will be uploaded again", map[string]any{
+			"volid":    data.VolID.ValueString(),
+			"expected": data.Size.ValueInt64(),
+			"got":      volume.Size,
+		})
+		data.Checksum = types.StringValue("")
+		data.Size = types.Int64Value(volume.Size)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only stores the plan, every change requires the
+// file to be uploaded again.
+func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := client.DeleteStorageVolume(ctx, data.Node.ValueString(), data.Storage.ValueString(), data.VolID.ValueString())
+	if err != nil && !proxmox.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// openSource opens the file source, the caller must close it.
+func (m FileResourceModel) openSource() (io.ReadCloser, int64, error) {
+	if !m.SourceContent.IsNull() {
+		content := m.SourceContent.ValueString()
+		return io.NopCloser(strings.NewReader(content)), int64(len(content)), nil
+	}
+
+	f, err := os.Open(m.SourceFile.ValueString())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, 0, fmt.Errorf("source file %s is a directory", m.SourceFile.ValueString())
+	}
+
+	return f, info.Size(), nil
+}
+
+// sourceChecksum returns the sha256 checksum of the file source,
+// large files are hashed without being loaded in memory.
+func (m FileResourceModel) sourceChecksum() (string, error) {
+	source, _, err := m.openSource()
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, source); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,834 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-proxmox/internal/provider/clusterattr"
+	"terraform-provider-proxmox/internal/provider/optional"
+	"terraform-provider-proxmox/internal/provider/preflight"
+	"terraform-provider-proxmox/internal/provider/validators"
+	"terraform-provider-proxmox/internal/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StorageResource{}
+var _ resource.ResourceWithImportState = &StorageResource{}
+var _ resource.ResourceWithModifyPlan = &StorageResource{}
+var _ resource.ResourceWithValidateConfig = &StorageResource{}
+
+func NewStorageResource() resource.Resource {
+	return &StorageResource{}
+}
+
+// storageTypeAttributes lists the storage types supported by
+// the type specific attributes.
+var storageTypeAttributes = map[string][]string{
+	"path":           {"dir"},
+	"vg_name":        {"lvmthin"},
+	"thin_pool":      {"lvmthin"},
+	"pool":           {"zfspool"},
+	"sparse":         {"zfspool"},
+	"server":         {"nfs", "cifs", "pbs"},
+	"export":         {"nfs"},
+	"options":        {"nfs"},
+	"share":          {"cifs"},
+	"domain":         {"cifs"},
+	"smb_version":    {"cifs"},
+	"subdir":         {"cifs", "cephfs"},
+	"username":       {"cifs", "pbs", "cephfs"},
+	"password":       {"cifs", "pbs"},
+	"datastore":      {"pbs"},
+	"namespace":      {"pbs"},
+	"fingerprint":    {"pbs"},
+	"encryption_key": {"pbs"},
+	"monhost":        {"cephfs"},
+	"keyring":        {"cephfs"},
+	"fs_name":        {"cephfs"},
+}
+
+// storageContentTypes lists the supported storage content types.
+var storageContentTypes = []string{"images", "rootdir", "vztmpl", "iso", "backup", "snippets", "import"}
+
+// storageTypeRequired lists the attributes required by each
+// storage type.
+var storageTypeRequired = map[string][]string{
+	"dir":     {"path"},
+	"lvmthin": {"vg_name", "thin_pool"},
+	"zfspool": {"pool"},
+	"nfs":     {"server", "export"},
+	"cifs":    {"server", "share"},
+	"pbs":     {"server", "datastore", "username"},
+}
+
+// StorageResource defines the resource implementation.
+type StorageResource struct {
+	client *proxmox.Client
+}
+
+// StorageResourceModel describes the resource data model.
+type StorageResourceModel struct {
+	Cluster       types.String `tfsdk:"cluster"`
+	ID            types.String `tfsdk:"id"`
+	Storage       types.String `tfsdk:"storage"`
+	Type          types.String `tfsdk:"type"`
+	Content       types.Set    `tfsdk:"content"`
+	Nodes         types.Set    `tfsdk:"nodes"`
+	Shared        types.Bool   `tfsdk:"shared"`
+	Disable       types.Bool   `tfsdk:"disable"`
+	PruneBackups  types.Object `tfsdk:"prune_backups"`
+	Path          types.String `tfsdk:"path"`
+	VGName        types.String `tfsdk:"vg_name"`
+	ThinPool      types.String `tfsdk:"thin_pool"`
+	Pool          types.String `tfsdk:"pool"`
+	Sparse        types.Bool   `tfsdk:"sparse"`
+	Server        types.String `tfsdk:"server"`
+	Export        types.String `tfsdk:"export"`
+	Options       types.String `tfsdk:"options"`
+	Share         types.String `tfsdk:"share"`
+	Domain        types.String `tfsdk:"domain"`
+	SMBVersion    types.String `tfsdk:"smb_version"`
+	Subdir        types.String `tfsdk:"subdir"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	Datastore     types.String `tfsdk:"datastore"`
+	Namespace     types.String `tfsdk:"namespace"`
+	Fingerprint   types.String `tfsdk:"fingerprint"`
+	EncryptionKey types.String `tfsdk:"encryption_key"`
+	MonHost       types.String `tfsdk:"monhost"`
+	Keyring       types.String `tfsdk:"keyring"`
+	FSName        types.String `tfsdk:"fs_name"`
+}
+
+func (m StorageResourceModel) values() map[string]attr.Value {
+	return map[string]attr.Value{
+		"path":           m.Path,
+		"vg_name":        m.VGName,
+		"thin_pool":      m.ThinPool,
+		"pool":           m.Pool,
+		"sparse":         m.Sparse,
+		"server":         m.Server,
+		"export":         m.Export,
+		"options":        m.Options,
+		"share":          m.Share,
+		"domain":         m.Domain,
+		"smb_version":    m.SMBVersion,
+		"subdir":         m.Subdir,
+		"username":       m.Username,
+		"password":       m.Password,
+		"datastore":      m.Datastore,
+		"namespace":      m.Namespace,
+		"fingerprint":    m.Fingerprint,
+		"encryption_key": m.EncryptionKey,
+		"monhost":        m.MonHost,
+		"keyring":        m.Keyring,
+		"fs_name":        m.FSName,
+	}
+}
+
+// PruneBackupsModel describes the prune_backups data model.
+type PruneBackupsModel struct {
+	KeepAll     types.Bool  `tfsdk:"keep_all"`
+	KeepLast    types.Int64 `tfsdk:"keep_last"`
+	KeepHourly  types.Int64 `tfsdk:"keep_hourly"`
+	KeepDaily   types.Int64 `tfsdk:"keep_daily"`
+	KeepWeekly  types.Int64 `tfsdk:"keep_weekly"`
+	KeepMonthly types.Int64 `tfsdk:"keep_monthly"`
+	KeepYearly  types.Int64 `tfsdk:"keep_yearly"`
+}
+
+var pruneBackupsAttrTypes = map[string]attr.Type{
+	"keep_all":     types.BoolType,
+	"keep_last":    types.Int64Type,
+	"keep_hourly":  types.Int64Type,
+	"keep_daily":   types.Int64Type,
+	"keep_weekly":  types.Int64Type,
+	"keep_monthly": types.Int64Type,
+	"keep_yearly":  types.Int64Type,
+}
+
+func (m *PruneBackupsModel) LoadFromObject(ctx context.Context, obj types.Object) {
+	obj.As(ctx, m, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})
+}
+
+// LoadFromPVE parses the proxmox prune-backups property string,
+// i.e. "keep-last=3,keep-daily=7".
+func (m *PruneBackupsModel) LoadFromPVE(value string) error {
+	m.KeepAll = types.BoolNull()
+	m.KeepLast = types.Int64Null()
+	m.KeepHourly = types.Int64Null()
+	m.KeepDaily = types.Int64Null()
+	m.KeepWeekly = types.Int64Null()
+	m.KeepMonthly = types.Int64Null()
+	m.KeepYearly = types.Int64Null()
+
+	for _, kv := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(kv, "=")
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid prune-backups %s value %q", k, v)
+		}
+
+		switch k {
+		case "keep-all":
+			m.KeepAll = types.BoolValue(n == 1)
+		case "keep-last":
+			m.KeepLast = types.Int64Value(n)
+		case "keep-hourly":
+			m.KeepHourly = types.Int64Value(n)
+		case "keep-daily":
+			m.KeepDaily = types.Int64Value(n)
+		case "keep-weekly":
+			m.KeepWeekly = types.Int64Value(n)
+		case "keep-monthly":
+			m.KeepMonthly = types.Int64Value(n)
+		case "keep-yearly":
+			m.KeepYearly = types.Int64Value(n)
+		default:
+			return fmt.Errorf("unexpected prune-backups key %q", k)
+		}
+	}
+
+	return nil
+}
+
+func (m PruneBackupsModel) ToObject() types.Object {
+	object, _ := types.ObjectValueFrom(context.TODO(), pruneBackupsAttrTypes, m)
+	return object
+}
+
+// ToPVE formats the model as a proxmox property string.
+func (m PruneBackupsModel) ToPVE() string {
+	items := []string{}
+	if !m.KeepAll.IsNull() {
+		keepAll := 0
+		if m.KeepAll.ValueBool() {
+			keepAll = 1
+		}
+		items = append(items, fmt.Sprintf("keep-all=%d", keepAll))
+	}
+
+	keeps := []struct {
+		key   string
+		value types.Int64
+	}{
+		{"keep-last", m.KeepLast},
+		{"keep-hourly", m.KeepHourly},
+		{"keep-daily", m.KeepDaily},
+		{"keep-weekly", m.KeepWeekly},
+		{"keep-monthly", m.KeepMonthly},
+		{"keep-yearly", m.KeepYearly},
+	}
+	for _, keep := range keeps {
+		if !keep.value.IsNull() {
+			items = append(items, fmt.Sprintf("%s=%d", keep.key, keep.value.ValueInt64()))
+		}
+	}
+
+	return strings.Join(items, ",")
+}
+
+func (r *StorageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	name := "storage"
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, name)
+}
+
+func (r *StorageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	fixed := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	keep := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:    true,
+			Description: description,
+			Validators: []validator.Int64{
+				validators.Between(1, 1000000),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Storage resource",
+		Description:         DESC_STORAGE,
+		Attributes: map[string]schema.Attribute{
+			clusterattr.Name: clusterattr.ResourceAttribute(),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: DESC_STORAGE_ID,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_STORAGE_STORAGE,
+				Validators: []validator.String{
+					validators.Regex(
+						regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-\_\.]*[A-Za-z0-9]$`),
+						`value must match [A-Za-z][A-Za-z0-9\-\_\.]*[A-Za-z0-9]`,
+					),
+				},
+				PlanModifiers: fixed,
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: DESC_STORAGE_TYPE,
+				Validators: []validator.String{
+					validators.OneOf("dir", "lvmthin", "zfspool", "nfs", "cifs", "pbs", "cephfs"),
+				},
+				PlanModifiers: fixed,
+			},
+			"content": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_STORAGE_CONTENT,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nodes": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: DESC_STORAGE_NODES,
+			},
+			"shared": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: DESC_STORAGE_SHARED,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disable": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(DFLT_STORAGE_DISABLE),
+				Description: DESC_STORAGE_DISABLE,
+			},
+			"prune_backups": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_PRUNE_BACKUPS,
+				Attributes: map[string]schema.Attribute{
+					"keep_all": schema.BoolAttribute{
+						Optional:    true,
+						Description: DESC_STORAGE_PRUNE_KEEP_ALL,
+					},
+					"keep_last":    keep(DESC_STORAGE_PRUNE_KEEP_LAST),
+					"keep_hourly":  keep(DESC_STORAGE_PRUNE_KEEP_HOURLY),
+					"keep_daily":   keep(DESC_STORAGE_PRUNE_KEEP_DAILY),
+					"keep_weekly":  keep(DESC_STORAGE_PRUNE_KEEP_WEEKLY),
+					"keep_monthly": keep(DESC_STORAGE_PRUNE_KEEP_MONTHLY),
+					"keep_yearly":  keep(DESC_STORAGE_PRUNE_KEEP_YEARLY),
+				},
+			},
+			"path": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_PATH,
+				PlanModifiers: fixed,
+			},
+			"vg_name": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_VG_NAME,
+				PlanModifiers: fixed,
+			},
+			"thin_pool": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_THIN_POOL,
+				PlanModifiers: fixed,
+			},
+			"pool": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_POOL,
+				PlanModifiers: fixed,
+			},
+			"sparse": schema.BoolAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_SPARSE,
+			},
+			"server": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_SERVER,
+				PlanModifiers: fixed,
+			},
+			"export": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_EXPORT,
+				PlanModifiers: fixed,
+			},
+			"options": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_OPTIONS,
+			},
+			"share": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_SHARE,
+				PlanModifiers: fixed,
+			},
+			"domain": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_DOMAIN,
+			},
+			"smb_version": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_SMB_VERSION,
+				Validators: []validator.String{
+					validators.OneOf("default", "2.0", "2.1", "3", "3.0", "3.11"),
+				},
+			},
+			"subdir": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_SUBDIR,
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_USERNAME,
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: DESC_STORAGE_PASSWORD,
+			},
+			"datastore": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_DATASTORE,
+				PlanModifiers: fixed,
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_NAMESPACE,
+			},
+			"fingerprint": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_FINGERPRINT,
+			},
+			"encryption_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: DESC_STORAGE_ENCRYPTION_KEY,
+			},
+			"monhost": schema.StringAttribute{
+				Optional:    true,
+				Description: DESC_STORAGE_MONHOST,
+			},
+			"keyring": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: DESC_STORAGE_KEYRING,
+			},
+			"fs_name": schema.StringAttribute{
+				Optional:      true,
+				Description:   DESC_STORAGE_FS_NAME,
+				PlanModifiers: fixed,
+			},
+		},
+	}
+}
+
+func (r *StorageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*proxmox.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *proxmox.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig ensures the type specific attributes are only
+// used along with the storage types supporting them.
+func (r *StorageResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data StorageResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+	typ := data.Type.ValueString()
+	values := data.values()
+
+	for name, supported := range storageTypeAttributes {
+		if values[name].IsNull() || slices.Contains(supported, typ) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Unsupported Storage Attribute",
+			fmt.Sprintf("Attribute %s can only be used with the %s storage types, got: %q.",
+				name, strings.Join(supported, ", "), typ),
+		)
+	}
+
+	for _, name := range storageTypeRequired[typ] {
+		if !values[name].IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Missing Storage Attribute",
+			fmt.Sprintf("Attribute %s is required by the %s storage type.", name, typ),
+		)
+	}
+
+	if !data.Content.IsUnknown() {
+		for _, item := range data.Content.Elements() {
+			content, ok := item.(types.String)
+			if !ok || content.IsUnknown() || slices.Contains(storageContentTypes, content.ValueString()) {
+				continue
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content"),
+				"Invalid Storage Content",
+				fmt.Sprintf("Content type must be one of %s, got: %q.",
+					strings.Join(storageContentTypes, ", "), content.ValueString()),
+			)
+		}
+	}
+
+	if data.PruneBackups.IsNull() || data.PruneBackups.IsUnknown() {
+		return
+	}
+
+	prune := PruneBackupsModel{}
+	prune.LoadFromObject(ctx, data.PruneBackups)
+	if prune.KeepAll.ValueBool() && prune.ToPVE() != "keep-all=1" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prune_backups").AtName("keep_all"),
+			"Conflicting Prune Backups Options",
+			"Attribute prune_backups.keep_all cannot be enabled along with other retention options.",
+		)
+	}
+}
+
+// ModifyPlan reports the privileges missing to apply the changes.
+func (r *StorageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !preflight.Changes(req) {
+		return
+	}
+
+	client, ok := clusterattr.PlanClient(ctx, r.client, req, resp)
+	if !ok {
+		return
+	}
+	if preflight.Creates(req) {
+		preflight.Require(client, &resp.Diagnostics, "Datastore.Allocate", "/storage")
+		return
+	}
+	if storage, ok := preflight.PlannedString(ctx, resp, path.Root("storage")); ok {
+		preflight.Require(client, &resp.Diagnostics, "Datastore.Allocate", "/storage/"+storage)
+	}
+}
+
+func (r *StorageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StorageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := client.CreateStorage(ctx, data.toRequest(ctx)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create storage, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read storage, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StorageResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.read(ctx, client, &data)
+	if proxmox.IsNotFound(err) {
+		tflog.Warn(ctx, "storage not found, removing it from state", map[string]any{"storage": data.Storage.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read storage, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StorageResourceModel
+	var state StorageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiReq := data.toRequest(ctx)
+	params := []struct {
+		name    string
+		planned attr.Value
+		current attr.Value
+	}{
+		{"nodes", data.Nodes, state.Nodes},
+		{"prune-backups", data.PruneBackups, state.PruneBackups},
+		{"sparse", data.Sparse, state.Sparse},
+		{"options", data.Options, state.Options},
+		{"domain", data.Domain, state.Domain},
+		{"smbversion", data.SMBVersion, state.SMBVersion},
+		{"subdir", data.Subdir, state.Subdir},
+		{"username", data.Username, state.Username},
+		{"password", data.Password, state.Password},
+		{"namespace", data.Namespace, state.Namespace},
+		{"fingerprint", data.Fingerprint, state.Fingerprint},
+		{"encryption-key", data.EncryptionKey, state.EncryptionKey},
+		{"monhost", data.MonHost, state.MonHost},
+		{"keyring", data.Keyring, state.Keyring},
+	}
+	for _, param := range params {
+		if param.planned.IsNull() && !param.current.IsNull() {
+			apiReq.Delete = append(apiReq.Delete, param.name)
+		}
+	}
+
+	if err := client.UpdateStorage(ctx, apiReq); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update storage, got error: %s", err))
+		return
+	}
+
+	if err := r.read(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read storage, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StorageResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := clusterattr.Client(ctx, r.client, data.Cluster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := client.DeleteStorage(ctx, data.Storage.ValueString())
+	if err != nil && !proxmox.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete storage, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (r *StorageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	cluster, storage := clusterattr.ImportID(req.ID)
+	state := StorageResourceModel{
+		Cluster:      cluster,
+		Storage:      types.StringValue(storage),
+		Content:      types.SetNull(types.StringType),
+		Nodes:        types.SetNull(types.StringType),
+		PruneBackups: types.ObjectNull(pruneBackupsAttrTypes),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// read loads the remote storage config into data. Credentials
+// are kept as is, as proxmox does not return them.
+func (r *StorageResource) read(ctx context.Context, client *proxmox.Client, data *StorageResourceModel) error {
+	remote, err := client.GetStorage(ctx, data.Storage.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.ID = types.StringValue(remote.Storage)
+	data.Storage = types.StringValue(remote.Storage)
+	data.Type = types.StringValue(remote.Type)
+	data.Content = splitSet(remote.Content)
+	if data.Content.IsNull() {
+		data.Content = types.SetValueMust(types.StringType, []attr.Value{})
+	}
+	data.Nodes = splitSet(remote.Nodes)
+	data.Shared = types.BoolValue(remote.Shared == 1)
+	data.Disable = types.BoolValue(remote.Disable == 1)
+
+	data.PruneBackups = types.ObjectNull(pruneBackupsAttrTypes)
+	if remote.PruneBackups != "" {
+		prune := PruneBackupsModel{}
+		if err := prune.LoadFromPVE(remote.PruneBackups); err != nil {
+			return err
+		}
+		data.PruneBackups = prune.ToObject()
+	}
+
+	data.Path = optional.String(remote.Path)
+	data.VGName = optional.String(remote.VGName)
+	data.ThinPool = optional.String(remote.ThinPool)
+	data.Pool = optional.String(remote.Pool)
+	data.Server = optional.String(remote.Server)
+	data.Export = optional.String(remote.Export)
+	data.Options = optional.String(remote.Options)
+	data.Share = optional.String(remote.Share)
+	data.Domain = optional.String(remote.Domain)
+	data.SMBVersion = optional.String(remote.SMBVersion)
+	data.Subdir = optional.String(remote.Subdir)
+	data.Username = optional.String(remote.Username)
+	data.Datastore = optional.String(remote.Datastore)
+	data.Namespace = optional.String(remote.Namespace)
+	data.Fingerprint = optional.String(remote.Fingerprint)
+	data.MonHost = optional.String(remote.MonHost)
+	data.FSName = optional.String(remote.FSName)
+
+	// sparse is only returned when enabled.
+	if remote.Sparse == 1 || !data.Sparse.IsNull() {
+		data.Sparse = types.BoolValue(remote.Sparse == 1)
+	}
+
+	return nil
+}
+
+func (m StorageResourceModel) toRequest(ctx context.Context) proxmox.StorageRequest {
+	req := proxmox.StorageRequest{
+		Storage:       m.Storage.ValueString(),
+		Type:          m.Type.ValueString(),
+		Content:       joinSet(m.Content),
+		Nodes:         joinSet(m.Nodes),
+		Disable:       m.Disable.ValueBoolPointer(),
+		Path:          m.Path.ValueStringPointer(),
+		VGName:        m.VGName.ValueStringPointer(),
+		ThinPool:      m.ThinPool.ValueStringPointer(),
+		Pool:          m.Pool.ValueStringPointer(),
+		Sparse:        m.Sparse.ValueBoolPointer(),
+		Server:        m.Server.ValueStringPointer(),
+		Export:        m.Export.ValueStringPointer(),
+		Options:       m.Options.ValueStringPointer(),
+		Share:         m.Share.ValueStringPointer(),
+		Domain:        m.Domain.ValueStringPointer(),
+		SMBVersion:    m.SMBVersion.ValueStringPointer(),
+		Subdir:        m.Subdir.ValueStringPointer(),
+		Username:      m.Username.ValueStringPointer(),
+		Password:      m.Password.ValueStringPointer(),
+		Datastore:     m.Datastore.ValueStringPointer(),
+		Namespace:     m.Namespace.ValueStringPointer(),
+		Fingerprint:   m.Fingerprint.ValueStringPointer(),
+		EncryptionKey: m.EncryptionKey.ValueStringPointer(),
+		MonHost:       m.MonHost.ValueStringPointer(),
+		Keyring:       m.Keyring.ValueStringPointer(),
+		FSName:        m.FSName.ValueStringPointer(),
+	}
+
+	// shared is computed by proxmox for the network storages.
+	if !m.Shared.IsUnknown() && !m.Shared.IsNull() {
+		req.Shared = m.Shared.ValueBoolPointer()
+	}
+
+	if !m.PruneBackups.IsNull() && !m.PruneBackups.IsUnknown() {
+		prune := PruneBackupsModel{}
+		prune.LoadFromObject(ctx, m.PruneBackups)
+		value := prune.ToPVE()
+		req.PruneBackups = &value
+	}
+
+	return req
+}
+
+// joinSet formats a string set as a proxmox comma separated
+// list, nil is returned for null or unknown sets.
+func joinSet(set types.Set) *string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	items := []string{}
+	for _, item := range set.Elements() {
+		items = append(items, item.(types.String).ValueString())
+	}
+	value := strings.Join(items, ",")
+
+	return &value
+}
+
+// splitSet parses a proxmox comma separated list.
+func splitSet(value string) types.Set {
+	if value == "" {
+		return types.SetNull(types.StringType)
+	}
+
+	items := []attr.Value{}
+	for _, item := range strings.Split(value, ",") {
+		items = append(items, types.StringValue(strings.TrimSpace(item)))
+	}
+
+	return types.SetValueMust(types.StringType, items)
+}
@@ -0,0 +1,312 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String = firewallPortsValidator{}
+	_ validator.String = firewallAddressValidator{}
+	_ validator.String = firewallProtoValidator{}
+)
+
+var (
+	// firewall rule action or security group name.
+	firewallActionRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-\_]+$`)
+	// service names as defined in /etc/services.
+	firewallServiceRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-\_\+\.]*$`)
+	// protocol names as defined in /etc/protocols.
+	firewallProtoRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-\_\.]*$`)
+	// ip set reference, i.e. "+ipsetname" or "+dc/ipsetname".
+	firewallIPSetRegex = regexp.MustCompile(`^\+((dc|guest|sdn)/)?[A-Za-z][A-Za-z0-9\-\_]+$`)
+	// ip alias reference, i.e. "aliasname" or "dc/aliasname".
+	firewallAliasRegex = regexp.MustCompile(`^((dc|guest)/)?[A-Za-z][A-Za-z0-9\-\_]+$`)
+)
+
+// FirewallAction returns a validator for the firewall rule
+// action ('ACCEPT', 'DROP', 'REJECT') or security group name.
+func FirewallAction() validator.String {
+	return Regex(firewallActionRegex, `value must match [A-Za-z][A-Za-z0-9\-\_]+`)
+}
+
+// FirewallType returns a validator for the firewall rule type.
+func FirewallType() validator.String {
+	return OneOf("in", "out", "forward", "group")
+}
+
+// FirewallLogLevel returns a validator for the firewall rule
+// log level. Empty strings are allowed, as it's the default
+// value used by the firewall resources.
+func FirewallLogLevel() validator.String {
+	return OneOf("", "emerg", "alert", "crit", "err", "warning", "notice", "info", "debug", "nolog")
+}
+
+// FirewallProto returns a validator for the firewall rule
+// protocol, either a protocol name or a protocol number.
+func FirewallProto() validator.String {
+	return firewallProtoValidator{}
+}
+
+type firewallProtoValidator struct{}
+
+func (v firewallProtoValidator) Description(_ context.Context) string {
+	return "value must be a protocol name (i.e. 'tcp') or a protocol number (0-255)"
+}
+
+func (v firewallProtoValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v firewallProtoValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if value == "" || firewallProtoRegex.MatchString(value) {
+		return
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// FirewallPorts returns a validator for the firewall rule
+// dport and sport attributes. Values are a comma separated
+// list of service names, port numbers (0-65535) or port
+// ranges ('\d+:\d+').
+//
+// As proxmox only accepts ports along with a protocol, the
+// validator also ensures the sibling proto (or macro)
+// attribute is set.
+func FirewallPorts() validator.String {
+	return firewallPortsValidator{}
+}
+
+type firewallPortsValidator struct{}
+
+func (v firewallPortsValidator) Description(_ context.Context) string {
+	return `value must be a comma separated list of service names, ports (0-65535) or port ranges ('\d+:\d+')`
+}
+
+func (v firewallPortsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v firewallPortsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if value == "" {
+		return
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		if err := validateFirewallPort(strings.TrimSpace(entry)); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s, got: %q (%s)", req.Path, v.Description(ctx), value, err),
+			)
+			return
+		}
+	}
+
+	proto := siblingString(ctx, req, "proto")
+	macro := siblingString(ctx, req, "macro")
+	if proto.IsUnknown() || macro.IsUnknown() {
+		return
+	}
+	if proto.ValueString() == "" && macro.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Missing Firewall Rule Protocol",
+			fmt.Sprintf("Attribute %s can only be used along with a proto (i.e. 'tcp' or 'udp') or a macro.", req.Path),
+		)
+	}
+}
+
+func validateFirewallPort(entry string) error {
+	if entry == "" {
+		return fmt.Errorf("empty list entry")
+	}
+
+	if from, to, isRange := strings.Cut(entry, ":"); isRange {
+		start, err := parsePort(from)
+		if err != nil {
+			return err
+		}
+		end, err := parsePort(to)
+		if err != nil {
+			return err
+		}
+		if start > end {
+			return fmt.Errorf("range %q start is greater than its end", entry)
+		}
+		return nil
+	}
+
+	if _, err := strconv.Atoi(entry); err == nil {
+		_, err := parsePort(entry)
+		return err
+	}
+
+	if !firewallServiceRegex.MatchString(entry) {
+		return fmt.Errorf("invalid service name %q", entry)
+	}
+
+	return nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q", value)
+	}
+	if port < 0 || port > 65535 {
+		return 0, fmt.Errorf("port %d is out of range", port)
+	}
+	return port, nil
+}
+
+// FirewallAddress returns a validator for the firewall rule
+// source and destination attributes. Values can be an ip set
+// ('+ipsetname'), an ip alias, or a comma separated list of
+// ip addresses, networks and address ranges ('a.b.c.d-e.f.g.h')
+// that do not mix ipv4 and ipv6.
+func FirewallAddress() validator.String {
+	return firewallAddressValidator{}
+}
+
+type firewallAddressValidator struct{}
+
+func (v firewallAddressValidator) Description(_ context.Context) string {
+	return "value must be an ip set ('+ipsetname'), an ip alias or a comma separated " +
+		"list of ip addresses, networks and address ranges of the same ip version"
+}
+
+func (v firewallAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v firewallAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if value == "" {
+		return
+	}
+
+	if err := validateFirewallAddress(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q (%s)", req.Path, v.Description(ctx), value, err),
+		)
+	}
+}
+
+func validateFirewallAddress(value string) error {
+	entries := strings.Split(value, ",")
+
+	// ip sets and aliases cannot be part of a list.
+	if len(entries) == 1 {
+		entry := strings.TrimSpace(entries[0])
+		if firewallIPSetRegex.MatchString(entry) {
+			return nil
+		}
+		if strings.HasPrefix(entry, "+") {
+			return fmt.Errorf("invalid ip set name %q", entry)
+		}
+		if firewallAliasRegex.MatchString(entry) {
+			return nil
+		}
+	}
+
+	is4 := false
+	is6 := false
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		var addrs []netip.Addr
+		switch {
+		case entry == "":
+			return fmt.Errorf("empty list entry")
+		case strings.Contains(entry, "/"):
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return fmt.Errorf("invalid network %q", entry)
+			}
+			addrs = append(addrs, prefix.Addr())
+		case strings.Contains(entry, "-"):
+			from, to, _ := strings.Cut(entry, "-")
+			start, err := netip.ParseAddr(from)
+			if err != nil {
+				return fmt.Errorf("invalid address range %q", entry)
+			}
+			end, err := netip.ParseAddr(to)
+			if err != nil {
+				return fmt.Errorf("invalid address range %q", entry)
+			}
+			if start.Is4() != end.Is4() {
+				return fmt.Errorf("address range %q mixes ipv4 and ipv6", entry)
+			}
+			if start.Compare(end) > 0 {
+				return fmt.Errorf("address range %q start is greater than its end", entry)
+			}
+			addrs = append(addrs, start, end)
+		default:
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return fmt.Errorf("invalid address %q, ip sets and aliases cannot be part of a list", entry)
+			}
+			addrs = append(addrs, addr)
+		}
+
+		for _, addr := range addrs {
+			if addr.Is4() {
+				is4 = true
+			} else {
+				is6 = true
+			}
+		}
+		if is4 && is6 {
+			return fmt.Errorf("ipv4 and ipv6 addresses cannot be mixed")
+		}
+	}
+
+	return nil
+}
+
+// siblingString retrieves the value of an attribute living
+// next to the validated one, which allows the validators to
+// be used both in top level and nested attributes.
+func siblingString(ctx context.Context, req validator.StringRequest, name string) types.String {
+	var value types.String
+
+	diags := req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName(name), &value)
+	if diags.HasError() {
+		return types.StringNull()
+	}
+
+	return value
+}
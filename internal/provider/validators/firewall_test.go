@@ -0,0 +1,124 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func validateString(v validator.String, value types.String) bool {
+	req := validator.StringRequest{Path: path.Root("test"), ConfigValue: value}
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), req, resp)
+	return !resp.Diagnostics.HasError()
+}
+
+func TestFirewallValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		valid     bool
+	}{
+		{"action accept", FirewallAction(), types.StringValue("ACCEPT"), true},
+		{"action security group", FirewallAction(), types.StringValue("web-servers_1"), true},
+		{"action leading digit", FirewallAction(), types.StringValue("1group"), false},
+		{"action single char", FirewallAction(), types.StringValue("A"), false},
+		{"action null", FirewallAction(), types.StringNull(), true},
+		{"type in", FirewallType(), types.StringValue("in"), true},
+		{"type group", FirewallType(), types.StringValue("group"), true},
+		{"type invalid", FirewallType(), types.StringValue("IN"), false},
+		{"log empty", FirewallLogLevel(), types.StringValue(""), true},
+		{"log nolog", FirewallLogLevel(), types.StringValue("nolog"), true},
+		{"log invalid", FirewallLogLevel(), types.StringValue("verbose"), false},
+		{"proto name", FirewallProto(), types.StringValue("tcp"), true},
+		{"proto number", FirewallProto(), types.StringValue("255"), true},
+		{"proto out of range", FirewallProto(), types.StringValue("256"), false},
+		{"proto negative", FirewallProto(), types.StringValue("-1"), false},
+		{"proto empty", FirewallProto(), types.StringValue(""), true},
+		{"proto unknown", FirewallProto(), types.StringUnknown(), true},
+		{"address ip set", FirewallAddress(), types.StringValue("+dc/trusted"), true},
+		{"address invalid ip set", FirewallAddress(), types.StringValue("+1set"), false},
+		{"address list", FirewallAddress(), types.StringValue("10.0.0.1, 10.0.1.0/24"), true},
+		{"address mixed versions", FirewallAddress(), types.StringValue("10.0.0.1,fd00::1"), false},
+		{"address empty", FirewallAddress(), types.StringValue(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateString(tt.validator, tt.value); got != tt.valid {
+				t.Errorf("valid = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateFirewallPort(t *testing.T) {
+	tests := []struct {
+		entry string
+		valid bool
+	}{
+		{"22", true},
+		{"0", true},
+		{"65535", true},
+		{"65536", false},
+		{"-1", false},
+		{"8000:8080", true},
+		{"8080:8000", false},
+		{"8000:", false},
+		{"80:99999", false},
+		{"ssh", true},
+		{"http-alt", true},
+		{"1ssh", false},
+		{"ss h", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			err := validateFirewallPort(tt.entry)
+			if (err == nil) != tt.valid {
+				t.Errorf("validateFirewallPort(%q) error = %v, want valid %v", tt.entry, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateFirewallAddress(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"+ipset", true},
+		{"+guest/ipset", true},
+		{"+sdn/ipset", true},
+		{"+other/ipset", false},
+		{"alias", true},
+		{"dc/alias", true},
+		{"10.0.0.1", true},
+		{"10.0.0.0/8", true},
+		{"10.0.0.0/33", false},
+		{"fd00::/64", true},
+		{"10.0.0.1-10.0.0.9", true},
+		{"10.0.0.9-10.0.0.1", false},
+		{"10.0.0.1-fd00::1", false},
+		{"10.0.0.1-nope", false},
+		{"10.0.0.1,fd00::1", false},
+		{"fd00::1,fd00::2", true},
+		{"10.0.0.1,alias", false},
+		{"10.0.0.1,", false},
+		{"10.0.0.256", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			err := validateFirewallAddress(tt.value)
+			if (err == nil) != tt.valid {
+				t.Errorf("validateFirewallAddress(%q) error = %v, want valid %v", tt.value, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var _ validator.Int64 = betweenValidator{}
+
+// Between returns a validator which ensures that a configured
+// integer is within the [min, max] range.
+func Between(min, max int64) validator.Int64 {
+	return betweenValidator{min: min, max: max}
+}
+
+type betweenValidator struct {
+	min int64
+	max int64
+}
+
+func (v betweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v betweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v betweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueInt64()
+	if value >= v.min && value <= v.max {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), value),
+	)
+}
@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String = cidrValidator{}
+	_ validator.String = ipAddressValidator{}
+)
+
+// CIDR returns a validator which ensures that a configured
+// string is an address in cidr notation (i.e. '10.0.0.1/24').
+// version restricts the ip version (4 or 6), 0 allows both.
+func CIDR(version int) validator.String {
+	return cidrValidator{version: version}
+}
+
+type cidrValidator struct {
+	version int
+}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be %s address in cidr notation", ipVersionArticle(v.version))
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	prefix, err := netip.ParsePrefix(value)
+	if err == nil && matchesIPVersion(prefix.Addr(), v.version) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// IPAddress returns a validator which ensures that a configured
+// string is an ip address. version restricts the ip version
+// (4 or 6), 0 allows both.
+func IPAddress(version int) validator.String {
+	return ipAddressValidator{version: version}
+}
+
+type ipAddressValidator struct {
+	version int
+}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be %s address", ipVersionArticle(v.version))
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	addr, err := netip.ParseAddr(value)
+	if err == nil && matchesIPVersion(addr, v.version) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+func matchesIPVersion(addr netip.Addr, version int) bool {
+	switch version {
+	case 4:
+		return addr.Is4()
+	case 6:
+		return addr.Is6() && !addr.Is4In6()
+	}
+	return true
+}
+
+func ipVersionArticle(version int) string {
+	switch version {
+	case 4:
+		return "an ipv4"
+	case 6:
+		return "an ipv6"
+	}
+	return "an ip"
+}
@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String = oneOfValidator{}
+	_ validator.String = regexValidator{}
+)
+
+// OneOf returns a validator which ensures that a configured
+// string is equal to one of the given values.
+func OneOf(values ...string) validator.String {
+	return oneOfValidator{values: values}
+}
+
+type oneOfValidator struct {
+	values []string
+}
+
+func (v oneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, " | "))
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// Regex returns a validator which ensures that a configured
+// string matches the given regular expression. The message
+// describes the expected format in the diagnostics.
+func Regex(re *regexp.Regexp, message string) validator.String {
+	return regexValidator{re: re, message: message}
+}
+
+type regexValidator struct {
+	re      *regexp.Regexp
+	message string
+}
+
+func (v regexValidator) Description(_ context.Context) string {
+	if v.message != "" {
+		return v.message
+	}
+	return fmt.Sprintf("value must match regular expression '%s'", v.re)
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if v.re.MatchString(value) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
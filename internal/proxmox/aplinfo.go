@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Appliance maps the GET /nodes/{node}/aplinfo response data,
+// i.e. the entries of the container template index.
+type Appliance struct {
+	Template     string `json:"template"`
+	Package      string `json:"package"`
+	Version      string `json:"version"`
+	OS           string `json:"os"`
+	Section      string `json:"section"`
+	Type         string `json:"type"`
+	Headline     string `json:"headline"`
+	Location     string `json:"location"`
+	SHA512Sum    string `json:"sha512sum"`
+	MD5Sum       string `json:"md5sum"`
+	Architecture string `json:"architecture"`
+}
+
+// GetAppliances lists the templates available in the node
+// appliance index.
+func (c *Client) GetAppliances(ctx context.Context, node string) ([]Appliance, error) {
+	res := []Appliance{}
+	if err := c.Get(ctx, fmt.Sprintf("/nodes/%s/aplinfo", url.PathEscape(node)), nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DownloadAppliance downloads a template from the appliance index
+// into a node storage and waits for the download task.
+func (c *Client) DownloadAppliance(ctx context.Context, node, storage, template string) error {
+	params := url.Values{}
+	params.Set("storage", storage)
+	params.Set("template", template)
+
+	upid := ""
+	if err := c.Post(ctx, fmt.Sprintf("/nodes/%s/aplinfo", url.PathEscape(node)), params, &upid); err != nil {
+		return err
+	}
+	return c.WaitTask(ctx, upid)
+}
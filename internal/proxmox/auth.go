@@ -0,0 +1,229 @@
+package proxmox
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auth modes of the client.
+const (
+	AuthModeToken  = "api_token"
+	AuthModeTicket = "ticket"
+)
+
+// ticketRenewAfter is the age after which a ticket is renewed,
+// proxmox tickets are valid for two hours.
+const ticketRenewAfter = time.Hour
+
+// ticketAuth holds the credentials and the current ticket of the
+// ticket (username/password) authentication.
+type ticketAuth struct {
+	username string
+	password string
+	// otp is either a TOTP code or the base32 TOTP secret, the
+	// secret allows to log in again once the ticket expired.
+	otp string
+
+	mu        sync.Mutex
+	ticket    string
+	csrfToken string
+	issuedAt  time.Time
+}
+
+// ticketResponse maps the POST /access/ticket response data.
+type ticketResponse struct {
+	Username  string `json:"username"`
+	Ticket    string `json:"ticket"`
+	CSRFToken string `json:"CSRFPreventionToken"`
+	NeedTFA   Int    `json:"NeedTFA"`
+}
+
+// AuthMode returns the authentication mode of the client,
+// either AuthModeToken or AuthModeTicket.
+func (c *Client) AuthMode() string {
+	if c.ticket != nil {
+		return AuthModeTicket
+	}
+	return AuthModeToken
+}
+
+// Login obtains a ticket when the client uses the ticket
+// authentication, it is a no-op otherwise. Tickets are renewed
+// automatically, Login only allows to report invalid
+// credentials early.
+func (c *Client) Login(ctx context.Context) error {
+	if c.ticket == nil {
+		return nil
+	}
+
+	c.ticket.mu.Lock()
+	defer c.ticket.mu.Unlock()
+
+	return c.login(ctx)
+}
+
+// authenticate sets the ticket cookie and the CSRF token of req,
+// the ticket is renewed first when needed. When the token is read
+// from a file or a process, its current value is set instead.
+func (c *Client) authenticate(req *http.Request) error {
+	if c.ticket == nil && c.tokens != nil {
+		creds, err := c.tokens.get(req.Context())
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", creds.header())
+		return nil
+	}
+	if c.ticket == nil {
+		return nil
+	}
+
+	c.ticket.mu.Lock()
+	defer c.ticket.mu.Unlock()
+
+	if c.ticket.ticket == "" || time.Since(c.ticket.issuedAt) > ticketRenewAfter {
+		if err := c.login(req.Context()); err != nil {
+			return err
+		}
+	}
+
+	req.AddCookie(&http.Cookie{Name: "PVEAuthCookie", Value: c.ticket.ticket})
+	if req.Method != http.MethodGet {
+		req.Header.Set("CSRFPreventionToken", c.ticket.csrfToken)
+	}
+	return nil
+}
+
+// login obtains a new ticket, the current one is renewed when
+// still valid (which does not require the second factor).
+// The caller must hold c.ticket.mu.
+func (c *Client) login(ctx context.Context) error {
+	auth := c.ticket
+
+	if auth.ticket != "" && time.Since(auth.issuedAt) < ticketRenewAfter*2 {
+		res, err := c.requestTicket(ctx, url.Values{
+			"username": {auth.username},
+			"password": {auth.ticket},
+		})
+		if err == nil {
+			auth.setTicket(res)
+			return nil
+		}
+	}
+
+	res, err := c.requestTicket(ctx, url.Values{
+		"username":   {auth.username},
+		"password":   {auth.password},
+		"new-format": {"1"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.NeedTFA == 1 {
+		if auth.otp == "" {
+			return fmt.Errorf("user %s requires a second factor, set the otp", auth.username)
+		}
+		code, err := totpCode(auth.otp, time.Now())
+		if err != nil {
+			return err
+		}
+		res, err = c.requestTicket(ctx, url.Values{
+			"username":      {auth.username},
+			"tfa-challenge": {res.Ticket},
+			"password":      {"totp:" + code},
+			"new-format":    {"1"},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	auth.setTicket(res)
+	return nil
+}
+
+func (a *ticketAuth) setTicket(res *ticketResponse) {
+	a.ticket = res.Ticket
+	a.csrfToken = res.CSRFToken
+	a.issuedAt = time.Now()
+}
+
+// requestTicket sends a POST /access/ticket request, it does not
+// go through send as the request is not authenticated.
+func (c *Client) requestTicket(ctx context.Context, params url.Values) (*ticketResponse, error) {
+	path := "/access/ticket"
+	_, endpoint := c.endpoint()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.baseURL()+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		logAPICall(ctx, apiLog{method: http.MethodPost, path: path, params: params, duration: time.Since(start), err: err})
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	logAPICall(ctx, apiLog{method: http.MethodPost, path: path, params: params, status: res.StatusCode, duration: time.Since(start), body: b, err: err})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(http.MethodPost, path, res, b)
+	}
+
+	envelope := struct {
+		Data *ticketResponse `json:"data"`
+	}{}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, fmt.Errorf("unable to decode POST %s response: %w", path, err)
+	}
+	if envelope.Data == nil || envelope.Data.Ticket == "" {
+		return nil, fmt.Errorf("POST %s: authentication failure", path)
+	}
+
+	return envelope.Data, nil
+}
+
+// totpCode returns otp when it is already a code, otherwise otp is
+// the base32 secret the RFC 6238 code of now is computed from.
+func totpCode(otp string, now time.Time) (string, error) {
+	if strings.Trim(otp, "0123456789") == "" {
+		return otp, nil
+	}
+
+	secret := strings.ToUpper(strings.ReplaceAll(otp, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(now.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
@@ -0,0 +1,42 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// base32 of the RFC 6238 sha1 secret "12345678901234567890", the
+	// expected codes are the last 6 digits of the RFC test vectors.
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		name    string
+		otp     string
+		unix    int64
+		want    string
+		wantErr bool
+	}{
+		{name: "rfc 59", otp: secret, unix: 59, want: "287082"},
+		{name: "rfc 1111111109", otp: secret, unix: 1111111109, want: "081804"},
+		{name: "rfc 1111111111", otp: secret, unix: 1111111111, want: "050471"},
+		{name: "rfc 1234567890", otp: secret, unix: 1234567890, want: "005924"},
+		{name: "rfc 2000000000", otp: secret, unix: 2000000000, want: "279037"},
+		{name: "rfc 20000000000", otp: secret, unix: 20000000000, want: "353130"},
+		{name: "lower case with spaces", otp: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", unix: 59, want: "287082"},
+		{name: "code", otp: "123456", unix: 59, want: "123456"},
+		{name: "invalid secret", otp: "not a secret!", unix: 59, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := totpCode(tt.otp, time.Unix(tt.unix, 0))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("totpCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("totpCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
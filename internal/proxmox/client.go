@@ -0,0 +1,464 @@
+package proxmox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iolave/go-proxmox/pkg/pve"
+)
+
+// Config holds the values required to build a Client.
+type Config struct {
+	Host               string
+	Port               int
+	InsecureSkipVerify bool
+	User               string
+	TokenName          string
+	Token              string
+	CfClientID         string
+	CfClientSecret     string
+
+	// TokenFile and CredentialProcess read the api token from a
+	// file or from the json output of a command instead of Token,
+	// they are read again once the token expires.
+	TokenFile         string
+	CredentialProcess []string
+
+	// Endpoints are the cluster members the api calls fail over
+	// to, Host and Port are used when empty.
+	Endpoints []Endpoint
+
+	// HTTPProxy, SOCKS5Proxy and SSHTunnel dial the api through
+	// a proxy or a bastion, only one of them can be set.
+	HTTPProxy   string
+	SOCKS5Proxy string
+	SSHTunnel   *SSHTunnel
+
+	// CACertPEM is the CA bundle trusted by the provider api
+	// calls instead of the system roots.
+	CACertPEM string
+	// TLSFingerprintSHA256 pins the certificates of the api as a
+	// comma separated list of fingerprints, a certificate matching
+	// any of them is trusted regardless of its chain.
+	TLSFingerprintSHA256 string
+	// ClientCertPEM and ClientKeyPEM are the client certificate
+	// presented by the provider api calls (mutual tls).
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	// Username, Password and OTP enable the ticket authentication
+	// of the provider api calls, they take precedence over the
+	// token. OTP is either a TOTP code or the base32 TOTP secret.
+	Username string
+	Password string
+	OTP      string
+
+	// VMIDRange bounds the vmids allocated by the provider,
+	// DefaultVMIDRange is used when zero.
+	VMIDRange VMIDRange
+	// RetryPolicy configures the retries of the failed api
+	// calls, DefaultRetryPolicy is used when nil.
+	RetryPolicy *RetryPolicy
+	// MaxRequestsPerSecond limits the rate of the api calls,
+	// 0 means unlimited.
+	MaxRequestsPerSecond float64
+
+	// NodeConcurrency bounds the number of concurrent guest
+	// operations per node, 0 means unlimited.
+	NodeConcurrency int
+
+	// Defaults are the provider level resource defaults.
+	Defaults Defaults
+
+	// Clusters are the configs of the other clusters managed
+	// by the provider, their clients are returned by SelectCluster.
+	Clusters map[string]Config
+
+	// Preflight makes SelectCluster run the preflight check of the
+	// clients it creates.
+	Preflight bool
+}
+
+// Client wraps the go-proxmox client and adds support for the
+// api endpoints that are not implemented by go-proxmox yet.
+//
+// Every go-proxmox service (LXC, Node, Cluster, ...) is available
+// through the embedded *pve.PVE, its calls go through the client
+// gateway.
+type Client struct {
+	*pve.PVE
+
+	// Locks serializes the conflicting operations of the
+	// resources sharing the client.
+	Locks *Locks
+	// Defaults are the values the resources fall back to when
+	// their matching attribute is not set.
+	Defaults Defaults
+
+	// logCtx carries the logger of the go-proxmox calls, which
+	// have no context.
+	logCtx context.Context
+
+	// gateway serves the go-proxmox calls, gatewaySecret is the
+	// token they are authorized with.
+	gateway       *http.Server
+	gatewaySecret string
+
+	endpoints  []Endpoint
+	endpointMu sync.Mutex
+	current    int
+
+	header     http.Header
+	httpClient *http.Client
+	ticket     *ticketAuth
+	tokens     *tokenSource
+
+	retryPolicy RetryPolicy
+	limiter     *rateLimiter
+
+	clusters    *clusterSet
+	permissions Permissions
+
+	vmidRange    VMIDRange
+	vmidMu       sync.Mutex
+	vmidReserved map[int]bool
+}
+
+// New creates both the go-proxmox client and the http client
+// used for the api calls that are done directly by the provider.
+// The go-proxmox calls are logged with ctx, it is not canceled with
+// it.
+func New(ctx context.Context, cfg Config) (*Client, error) {
+	header := http.Header{}
+	var ticket *ticketAuth
+	tokens := newTokenSource(cfg)
+	creds := tokenCreds{user: cfg.User, tokenName: cfg.TokenName, token: cfg.Token}
+	if tokens != nil {
+		var err error
+		if creds, err = tokens.get(ctx); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case cfg.Password != "":
+		ticket = &ticketAuth{
+			username: cfg.Username,
+			password: cfg.Password,
+			otp:      cfg.OTP,
+		}
+	case tokens == nil:
+		header.Set("Authorization", creds.header())
+	}
+	if cfg.CfClientID != "" && cfg.CfClientSecret != "" {
+		header.Set("CF-Access-Client-Id", cfg.CfClientID)
+		header.Set("CF-Access-Client-Secret", cfg.CfClientSecret)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+	dial, err := newDialer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if dial != nil {
+		transport.Proxy = nil
+		transport.DialContext = dial
+	}
+
+	vmidRange := cfg.VMIDRange
+	if vmidRange == (VMIDRange{}) {
+		vmidRange = DefaultVMIDRange
+	}
+	if err := vmidRange.Validate(); err != nil {
+		return nil, err
+	}
+
+	var clusters *clusterSet
+	if len(cfg.Clusters) > 0 {
+		clusters = &clusterSet{configs: cfg.Clusters, clients: map[string]*Client{}}
+	}
+
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []Endpoint{{Host: cfg.Host, Port: cfg.Port}}
+	}
+
+	retryPolicy := DefaultRetryPolicy
+	if cfg.RetryPolicy != nil {
+		retryPolicy = *cfg.RetryPolicy
+	}
+
+	c := &Client{
+		Locks:     NewLocks(cfg.NodeConcurrency),
+		Defaults:  cfg.Defaults,
+		logCtx:    context.WithoutCancel(ctx),
+		endpoints: endpoints,
+		header:    header,
+		ticket:    ticket,
+		tokens:    tokens,
+		httpClient: &http.Client{
+			Timeout:   time.Minute * 5,
+			Transport: transport,
+		},
+		retryPolicy:  retryPolicy,
+		clusters:     clusters,
+		limiter:      newRateLimiter(cfg.MaxRequestsPerSecond),
+		vmidRange:    vmidRange,
+		vmidReserved: map[int]bool{},
+	}
+	if clusters != nil {
+		clusters.owner = c
+	}
+
+	// go-proxmox is bound to the client gateway, which
+	// authenticates its calls and sends them to the current
+	// endpoint. It skips the verification of the gateway
+	// certificate, the api one is verified by the gateway.
+	port, err := c.serveGateway()
+	if err != nil {
+		return nil, err
+	}
+	pveConfig := pve.Config{
+		Host:               "127.0.0.1",
+		Port:               port,
+		InsecureSkipVerify: true,
+	}
+
+	// go-proxmox requires an api token, it is given the gateway
+	// secret instead of the api one. The gateway replaces it with
+	// the current client authentication, so the go-proxmox calls
+	// use the token read again once it expired.
+	pveCreds := pve.NewTokenCreds(creds.user, creds.tokenName, c.gatewaySecret)
+	if c.PVE, err = pve.NewWithCredentials(pveConfig, pveCreds); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Close shuts the client gateway down, along with the clients of
+// the clusters it created. The client cannot be used afterwards.
+func (c *Client) Close() error {
+	if c.clusters != nil && c.clusters.owner == c {
+		c.clusters.close()
+	}
+	c.httpClient.CloseIdleConnections()
+	if c.gateway == nil {
+		return nil
+	}
+	return c.gateway.Close()
+}
+
+// Get sends a GET request to the given api path and decodes
+// the response data into result (if not nil).
+func (c *Client) Get(ctx context.Context, path string, params url.Values, result any) error {
+	return c.do(ctx, http.MethodGet, path, params, result)
+}
+
+// Post sends a POST request to the given api path and decodes
+// the response data into result (if not nil).
+func (c *Client) Post(ctx context.Context, path string, params url.Values, result any) error {
+	return c.do(ctx, http.MethodPost, path, params, result)
+}
+
+// Put sends a PUT request to the given api path and decodes
+// the response data into result (if not nil).
+func (c *Client) Put(ctx context.Context, path string, params url.Values, result any) error {
+	return c.do(ctx, http.MethodPut, path, params, result)
+}
+
+// Delete sends a DELETE request to the given api path and decodes
+// the response data into result (if not nil).
+func (c *Client) Delete(ctx context.Context, path string, params url.Values, result any) error {
+	return c.do(ctx, http.MethodDelete, path, params, result)
+}
+
+// do sends the request according to the client retry policy,
+// POST requests are only retried on lock errors.
+func (c *Client) do(ctx context.Context, method, path string, params url.Values, result any) error {
+	fn := func() error {
+		return c.doFailover(ctx, method, path, params, result)
+	}
+	if method == http.MethodPost {
+		return c.RetryLocked(ctx, fn)
+	}
+	return c.Retry(ctx, fn)
+}
+
+// doFailover sends the request to the current endpoint, it is sent
+// again to the next healthy endpoint when it cannot reach the api.
+func (c *Client) doFailover(ctx context.Context, method, path string, params url.Values, result any) error {
+	return c.withFailover(ctx, method, func(endpoint Endpoint) error {
+		return c.doEndpoint(ctx, endpoint, method, path, params, result)
+	})
+}
+
+// withFailover runs send, a method request to endpoint, with the
+// current endpoint. It runs again with the next healthy endpoint
+// when the request cannot reach the api. Both the client and the
+// go-proxmox calls go through it.
+func (c *Client) withFailover(ctx context.Context, method string, send func(endpoint Endpoint) error) error {
+	renewed := false
+	for attempt := 1; ; attempt++ {
+		i, endpoint := c.endpoint()
+		err := send(endpoint)
+
+		// the token read from a file or a process might have
+		// expired, it is read again once.
+		if c.tokens != nil && !renewed && isUnauthorized(err) {
+			c.tokens.invalidate()
+			renewed = true
+			attempt--
+			continue
+		}
+		if attempt == len(c.endpoints) || !isConnectionError(method, err) || !c.failover(ctx, i) {
+			return err
+		}
+	}
+}
+
+func (c *Client) doEndpoint(ctx context.Context, endpoint Endpoint, method, path string, params url.Values, result any) error {
+	reqURL := endpoint.baseURL() + path
+
+	var body io.Reader
+	switch method {
+	case http.MethodGet, http.MethodDelete:
+		if len(params) > 0 {
+			reqURL = reqURL + "?" + params.Encode()
+		}
+	default:
+		body = strings.NewReader(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return c.send(c.httpClient, req, path, params, result)
+}
+
+// Upload sends a multipart POST request to the given api path,
+// streaming size bytes from file as the filename form field.
+// Uploads are only bounded by ctx, not by the client timeout, and
+// are not sent again to another endpoint as file is consumed.
+func (c *Client) Upload(ctx context.Context, path string, params url.Values, fileName string, file io.Reader, size int64, result any) error {
+	head := &bytes.Buffer{}
+	form := multipart.NewWriter(head)
+	for k, values := range params {
+		for _, v := range values {
+			if err := form.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := form.CreateFormFile("filename", fileName); err != nil {
+		return err
+	}
+
+	// the closing boundary is written apart so the file is
+	// streamed between the form head and tail.
+	tail := &bytes.Buffer{}
+	fmt.Fprintf(tail, "\r\n--%s--\r\n", form.Boundary())
+
+	body := io.MultiReader(head, io.LimitReader(file, size), tail)
+	_, endpoint := c.endpoint()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.baseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	// proxmox requires the content length of uploads.
+	req.ContentLength = int64(head.Len()) + size + int64(tail.Len())
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	uploadClient := &http.Client{Transport: c.httpClient.Transport}
+	return c.send(uploadClient, req, path, params, result)
+}
+
+// envelopeResult is implemented by the results decoded from the
+// whole response rather than from its data, i.e. the responses
+// carrying attributes next to the data.
+type envelopeResult interface {
+	envelope()
+}
+
+// send sends req using httpClient and decodes the response
+// data into result (if not nil). params are only used to log the
+// request.
+func (c *Client) send(httpClient *http.Client, req *http.Request, path string, params url.Values, result any) error {
+	method := req.Method
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	if err := c.authenticate(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	if err != nil {
+		logAPICall(req.Context(), apiLog{method: method, path: path, params: params, duration: time.Since(start), err: err})
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	logAPICall(req.Context(), apiLog{method: method, path: path, params: params, status: res.StatusCode, duration: time.Since(start), body: b, err: err})
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newAPIError(method, path, res, b)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if _, ok := result.(envelopeResult); ok {
+		if err := json.Unmarshal(b, result); err != nil {
+			return fmt.Errorf("unable to decode %s %s response: %w", method, path, err)
+		}
+		return nil
+	}
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return fmt.Errorf("unable to decode %s %s response: %w", method, path, err)
+	}
+	if len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("unable to decode %s %s response data: %w", method, path, err)
+	}
+
+	return nil
+}
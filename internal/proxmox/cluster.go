@@ -0,0 +1,46 @@
+package proxmox
+
+import (
+	"context"
+	"net/url"
+)
+
+// ClusterResource maps the GET /cluster/resources response data.
+type ClusterResource struct {
+	ID         string  `json:"id"`
+	Type       string  `json:"type"`
+	VMID       Int     `json:"vmid"`
+	Name       string  `json:"name"`
+	Node       string  `json:"node"`
+	Status     string  `json:"status"`
+	Tags       string  `json:"tags"`
+	Pool       string  `json:"pool"`
+	Template   Int     `json:"template"`
+	Storage    string  `json:"storage"`
+	Content    string  `json:"content"`
+	PluginType string  `json:"plugintype"`
+	Shared     Int     `json:"shared"`
+	SDN        string  `json:"sdn"`
+	CPU        float64 `json:"cpu"`
+	MaxCPU     float64 `json:"maxcpu"`
+	Mem        int64   `json:"mem"`
+	MaxMem     int64   `json:"maxmem"`
+	Disk       int64   `json:"disk"`
+	MaxDisk    int64   `json:"maxdisk"`
+	Uptime     int64   `json:"uptime"`
+}
+
+// GetClusterResources lists the cluster resources, typ filters
+// the listing when not empty (vm, storage, node or sdn).
+func (c *Client) GetClusterResources(ctx context.Context, typ string) ([]ClusterResource, error) {
+	params := url.Values{}
+	if typ != "" {
+		params.Set("type", typ)
+	}
+
+	res := []ClusterResource{}
+	if err := c.Get(ctx, "/cluster/resources", params, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package proxmox
+
+import "context"
+
+// GetClusterFirewallOptionsResponse maps the
+// GET /cluster/firewall/options response data.
+//
+// Options that were never set are not returned by proxmox,
+// in which case the pointer fields are nil.
+type GetClusterFirewallOptionsResponse struct {
+	Digest        string  `json:"digest"`
+	Ebtables      *int    `json:"ebtables"`
+	Enable        *int    `json:"enable"`
+	LogRatelimit  *string `json:"log_ratelimit"`
+	PolicyForward *string `json:"policy_forward"`
+	PolicyIn      *string `json:"policy_in"`
+	PolicyOut     *string `json:"policy_out"`
+}
+
+// UpdateClusterFirewallOptionsRequest maps the
+// PUT /cluster/firewall/options parameters.
+type UpdateClusterFirewallOptionsRequest struct {
+	// A list of settings you want to delete.
+	Delete []string `url:"delete,omitempty"`
+	// Prevent changes if current configuration file has
+	// a different digest.
+	Digest        string  `url:"digest,omitempty"`
+	Ebtables      *bool   `url:"ebtables"`
+	Enable        *bool   `url:"enable"`
+	LogRatelimit  *string `url:"log_ratelimit"`
+	PolicyForward *string `url:"policy_forward"`
+	PolicyIn      *string `url:"policy_in"`
+	PolicyOut     *string `url:"policy_out"`
+}
+
+// GetClusterFirewallOptions retrieves the datacenter
+// firewall options.
+func (c *Client) GetClusterFirewallOptions(ctx context.Context) (*GetClusterFirewallOptionsResponse, error) {
+	res := &GetClusterFirewallOptionsResponse{}
+	if err := c.Get(ctx, "/cluster/firewall/options", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateClusterFirewallOptions sets the datacenter
+// firewall options.
+func (c *Client) UpdateClusterFirewallOptions(ctx context.Context, req UpdateClusterFirewallOptionsRequest) error {
+	return c.Put(ctx, "/cluster/firewall/options", EncodeParams(req), nil)
+}
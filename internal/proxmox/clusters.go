@@ -0,0 +1,101 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// clusterSet holds the configs of the named clusters and their
+// clients, which are created on first use. It is shared by the
+// clients of a provider.
+type clusterSet struct {
+	configs map[string]Config
+	// owner is the provider client, the cluster clients are
+	// closed along with it.
+	owner *Client
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// SelectCluster returns the client of the named cluster (a Config
+// Clusters key), c itself when name is empty. The client is
+// created, its endpoint selected and its credentials checked (and
+// its preflight run when enabled) on first use.
+func (c *Client) SelectCluster(ctx context.Context, name string) (*Client, error) {
+	if name == "" {
+		return c, nil
+	}
+
+	set := c.clusters
+	if set == nil {
+		return nil, fmt.Errorf("unknown cluster %q, no clusters are configured", name)
+	}
+
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	if client, ok := set.clients[name]; ok {
+		return client, nil
+	}
+
+	cfg, ok := set.configs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q, expected one of %s", name, strings.Join(set.names(), ", "))
+	}
+
+	client, err := New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
+	if err := checkCluster(ctx, client, cfg); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
+	client.clusters = set
+
+	set.clients[name] = client
+	return client, nil
+}
+
+// checkCluster selects the endpoint of a cluster client and checks
+// its credentials, it runs its preflight when enabled.
+func checkCluster(ctx context.Context, client *Client, cfg Config) error {
+	if len(cfg.Endpoints) > 0 {
+		if _, err := client.SelectEndpoint(ctx); err != nil {
+			return err
+		}
+	}
+	if err := client.Login(ctx); err != nil {
+		return err
+	}
+	if cfg.Preflight {
+		if _, err := client.Preflight(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the cluster clients created so far.
+func (s *clusterSet) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, client := range s.clients {
+		client.Close()
+		delete(s.clients, name)
+	}
+}
+
+func (s *clusterSet) names() []string {
+	names := make([]string, 0, len(s.configs))
+	for name := range s.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,180 @@
+package proxmox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// credentialProcessTimeout bounds the credential process runs.
+	credentialProcessTimeout = time.Minute
+	// credentialExpirySkew renews the process credentials before
+	// they expire, so they do not expire during a call.
+	credentialExpirySkew = time.Second * 30
+)
+
+// tokenIDRe matches a full api token, i.e.
+// "root@pam!terraform=aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee".
+var tokenIDRe = regexp.MustCompile(`^([^!=\s]+)!([^!=\s]+)=(\S+)$`)
+
+// tokenCreds is an api token.
+type tokenCreds struct {
+	user      string
+	tokenName string
+	token     string
+}
+
+func (t tokenCreds) header() string {
+	return fmt.Sprintf("PVEAPIToken=%s!%s=%s", t.user, t.tokenName, t.token)
+}
+
+// credentialProcessOutput maps the json printed by a credential
+// process. User and TokenName fall back to the configured ones.
+type credentialProcessOutput struct {
+	User       string     `json:"user"`
+	TokenName  string     `json:"token_name"`
+	Token      string     `json:"token"`
+	Expiration *time.Time `json:"expiration"`
+}
+
+// tokenSource reads the api token from a file or a credential
+// process, it is read again once it expires (the file changed or
+// the process expiration passed) or the api rejects it.
+type tokenSource struct {
+	user      string
+	tokenName string
+	file      string
+	process   []string
+
+	mu        sync.Mutex
+	creds     tokenCreds
+	stale     bool
+	modTime   time.Time
+	expiresAt time.Time
+}
+
+func newTokenSource(cfg Config) *tokenSource {
+	if cfg.TokenFile == "" && len(cfg.CredentialProcess) == 0 {
+		return nil
+	}
+	return &tokenSource{
+		user:      cfg.User,
+		tokenName: cfg.TokenName,
+		file:      cfg.TokenFile,
+		process:   cfg.CredentialProcess,
+		stale:     true,
+	}
+}
+
+// get returns the current token, it is read again when expired.
+func (s *tokenSource) get(ctx context.Context) (tokenCreds, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file != "" {
+		info, err := os.Stat(s.file)
+		if err != nil {
+			return tokenCreds{}, fmt.Errorf("unable to read token file: %w", err)
+		}
+		if s.stale || !info.ModTime().Equal(s.modTime) {
+			if err := s.readFile(); err != nil {
+				return tokenCreds{}, err
+			}
+			s.modTime = info.ModTime()
+			s.stale = false
+		}
+		return s.creds, nil
+	}
+
+	if s.stale || (!s.expiresAt.IsZero() && time.Now().After(s.expiresAt.Add(-credentialExpirySkew))) {
+		if err := s.runProcess(ctx); err != nil {
+			return tokenCreds{}, err
+		}
+		s.stale = false
+	}
+	return s.creds, nil
+}
+
+// invalidate forces the token to be read again on the next call.
+func (s *tokenSource) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stale = true
+}
+
+// readFile reads the token file, it contains either the token
+// secret or the full token (user@realm!name=secret).
+func (s *tokenSource) readFile() error {
+	b, err := os.ReadFile(s.file)
+	if err != nil {
+		return fmt.Errorf("unable to read token file: %w", err)
+	}
+
+	value := strings.TrimPrefix(strings.TrimSpace(string(b)), "PVEAPIToken=")
+	creds := tokenCreds{user: s.user, tokenName: s.tokenName, token: value}
+	if match := tokenIDRe.FindStringSubmatch(value); match != nil {
+		creds = tokenCreds{user: match[1], tokenName: match[2], token: match[3]}
+	}
+	if creds.token == "" {
+		return fmt.Errorf("token file %s is empty", s.file)
+	}
+
+	s.creds = creds
+	return nil
+}
+
+// runProcess runs the credential process and decodes its output.
+func (s *tokenSource) runProcess(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, s.process[0], s.process[1:]...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("credential process %s failed: %w: %s", s.process[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	out := credentialProcessOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return fmt.Errorf("unable to decode credential process %s output: %w", s.process[0], err)
+	}
+
+	creds := tokenCreds{user: out.User, tokenName: out.TokenName, token: out.Token}
+	if creds.user == "" {
+		creds.user = s.user
+	}
+	if creds.tokenName == "" {
+		creds.tokenName = s.tokenName
+	}
+	if creds.user == "" || creds.tokenName == "" || creds.token == "" {
+		return fmt.Errorf("credential process %s output misses the user, token_name or token", s.process[0])
+	}
+
+	s.creds = creds
+	s.expiresAt = time.Time{}
+	if out.Expiration != nil {
+		s.expiresAt = *out.Expiration
+	}
+	return nil
+}
+
+// isUnauthorized reports whether err is an api authentication
+// failure.
+func isUnauthorized(err error) bool {
+	apiErr := &APIError{}
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
@@ -0,0 +1,11 @@
+package proxmox
+
+// Defaults are the values the resources fall back to when their
+// matching attribute is not set, they are resolved during the plan.
+type Defaults struct {
+	Node       string
+	Storage    string
+	Pool       string
+	Bridge     string
+	Nameserver string
+}
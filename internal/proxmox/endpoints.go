@@ -0,0 +1,166 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// endpointHealthTimeout bounds the endpoint health checks.
+const endpointHealthTimeout = time.Second * 5
+
+// Endpoint is the address of a cluster member api. Any member
+// serves the whole cluster api, the node bound calls are proxied
+// by proxmox to their node.
+type Endpoint struct {
+	Host string
+	Port int
+}
+
+// ParseEndpoint parses an endpoint formatted as host, host:port
+// or https://host:port, defaultPort is used when it has no port.
+func ParseEndpoint(value string, defaultPort int) (Endpoint, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("invalid endpoint %q: %w", value, err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" || (u.Path != "" && u.Path != "/") {
+		return Endpoint{}, fmt.Errorf("invalid endpoint %q, expected host, host:port or https://host:port", value)
+	}
+
+	endpoint := Endpoint{Host: u.Hostname(), Port: defaultPort}
+	if u.Port() != "" {
+		if endpoint.Port, err = strconv.Atoi(u.Port()); err != nil {
+			return Endpoint{}, fmt.Errorf("invalid endpoint %q port: %w", value, err)
+		}
+	}
+	return endpoint, nil
+}
+
+func (e Endpoint) String() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+func (e Endpoint) baseURL() string {
+	return fmt.Sprintf("https://%s/api2/json", e)
+}
+
+// Endpoint returns the endpoint the api calls are sent to.
+func (c *Client) Endpoint() Endpoint {
+	_, endpoint := c.endpoint()
+	return endpoint
+}
+
+// SelectEndpoint health checks the endpoints in order and selects
+// the first healthy one. The go-proxmox services follow it through
+// the client gateway.
+func (c *Client) SelectEndpoint(ctx context.Context) (Endpoint, error) {
+	errs := []error{}
+	for i, endpoint := range c.endpoints {
+		if err := c.checkEndpoint(ctx, endpoint); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
+
+		c.endpointMu.Lock()
+		c.current = i
+		c.endpointMu.Unlock()
+
+		return endpoint, nil
+	}
+
+	return Endpoint{}, fmt.Errorf("no healthy endpoint: %w", errors.Join(errs...))
+}
+
+// endpoint returns the current endpoint and its index.
+func (c *Client) endpoint() (int, Endpoint) {
+	c.endpointMu.Lock()
+	defer c.endpointMu.Unlock()
+
+	return c.current, c.endpoints[c.current]
+}
+
+// failover switches from the endpoint failed to the next healthy
+// one, it reports whether the calls can be sent again. The
+// endpoints are checked without holding the lock, so the other
+// calls are not stalled by the health checks.
+func (c *Client) failover(ctx context.Context, failed int) bool {
+	// a concurrent call already failed over.
+	if current, _ := c.endpoint(); current != failed {
+		return true
+	}
+
+	for n := 1; n < len(c.endpoints); n++ {
+		i := (failed + n) % len(c.endpoints)
+		if c.checkEndpoint(ctx, c.endpoints[i]) != nil {
+			continue
+		}
+
+		c.endpointMu.Lock()
+		defer c.endpointMu.Unlock()
+
+		// the endpoint selected by a concurrent failover is kept.
+		if c.current == failed {
+			c.current = i
+		}
+		return true
+	}
+	return false
+}
+
+// checkEndpoint reports whether the endpoint api answers, any
+// response but a server error is healthy as the request is not
+// authenticated.
+func (c *Client) checkEndpoint(ctx context.Context, endpoint Endpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointHealthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.baseURL()+"/version", nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.header {
+		if k != "Authorization" {
+			req.Header[k] = v
+		}
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unhealthy endpoint, got status %s", res.Status)
+	}
+	return nil
+}
+
+// isConnectionError reports whether err prevented a method request
+// from reaching the api. Only the connection failures are retried
+// for the requests that are not idempotent.
+func isConnectionError(method string, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	opErr := &net.OpError{}
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	urlErr := &url.Error{}
+	return method == http.MethodGet && errors.As(err, &urlErr)
+}
@@ -0,0 +1,54 @@
+package proxmox
+
+import "testing"
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Endpoint
+		wantErr bool
+	}{
+		{value: "pve1", want: Endpoint{Host: "pve1", Port: 8006}},
+		{value: " pve1 ", want: Endpoint{Host: "pve1", Port: 8006}},
+		{value: "pve1:443", want: Endpoint{Host: "pve1", Port: 443}},
+		{value: "https://pve1.example.com:8007", want: Endpoint{Host: "pve1.example.com", Port: 8007}},
+		{value: "https://pve1/", want: Endpoint{Host: "pve1", Port: 8006}},
+		{value: "10.0.0.1", want: Endpoint{Host: "10.0.0.1", Port: 8006}},
+		{value: "[fd00::1]:8006", want: Endpoint{Host: "fd00::1", Port: 8006}},
+		{value: "http://pve1:8006", wantErr: true},
+		{value: "https://pve1:8006/api2/json", wantErr: true},
+		{value: "pve1:port", wantErr: true},
+		{value: "https://:8006", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseEndpoint(tt.value, 8006)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEndpoint(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEndpoint(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointString(t *testing.T) {
+	tests := []struct {
+		endpoint Endpoint
+		want     string
+	}{
+		{Endpoint{Host: "pve1", Port: 8006}, "pve1:8006"},
+		{Endpoint{Host: "fd00::1", Port: 8006}, "[fd00::1]:8006"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.endpoint.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by the lookups that are not backed by
+// a single api object, i.e. a volume within a storage listing.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned when the proxmox api responds with
+// a status code >= 400.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the reason proxmox sets in the status line.
+	Message string
+	// Errors contains the parameter errors proxmox returns
+	// within the "errors" response field.
+	Errors map[string]string
+}
+
+func newAPIError(method, path string, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: res.StatusCode,
+		Message:    strings.TrimSpace(strings.TrimPrefix(res.Status, fmt.Sprintf("%d", res.StatusCode))),
+	}
+
+	envelope := struct {
+		Errors map[string]string `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		apiErr.Errors = envelope.Errors
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+	if len(e.Errors) == 0 {
+		return msg
+	}
+
+	keys := make([]string, 0, len(e.Errors))
+	for k := range e.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// the errors are listed one per line so they stand out in the
+	// diagnostics.
+	details := []string{msg}
+	for _, k := range keys {
+		details = append(details, fmt.Sprintf("  - %s: %s", k, strings.TrimSpace(e.Errors[k])))
+	}
+
+	return strings.Join(details, "\n")
+}
+
+// IsNotFound reports whether err is ErrNotFound or an api error
+// caused by a missing object.
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	// proxmox answers with a 500 status code for most of the
+	// missing objects, the reason is the only way to know.
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "not found") ||
+		strings.Contains(msg, "no such")
+}
@@ -0,0 +1,262 @@
+package proxmox
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gatewayHeaders are the go-proxmox request headers sent on to the
+// api, the others (i.e. its authorization) are set by the client.
+var gatewayHeaders = []string{"Accept", "Content-Type"}
+
+// gatewayResponse is an api response relayed to go-proxmox.
+type gatewayResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// serveGateway listens on a loopback port the go-proxmox services
+// are bound to, their requests are sent again to the current
+// endpoint with the client authentication (the api token or the
+// ticket), tls settings and transport. go-proxmox can neither be
+// given a transport nor a ticket, it only reaches the api through
+// the gateway.
+//
+// Any local process can connect to the port, the requests are only
+// sent on when they carry the gateway secret, which is only given
+// to go-proxmox (as its api token). The gateway is shut down by
+// Close.
+func (c *Client) serveGateway() (int, error) {
+	cert, err := gatewayCertificate()
+	if err != nil {
+		return 0, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return 0, err
+	}
+	c.gatewaySecret = hex.EncodeToString(secret)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		return 0, err
+	}
+
+	c.gateway = &http.Server{
+		Handler:           http.HandlerFunc(c.serveGatewayRequest),
+		ReadHeaderTimeout: time.Minute,
+	}
+	go c.gateway.Serve(l)
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func (c *Client) serveGatewayRequest(w http.ResponseWriter, r *http.Request) {
+	if !c.gatewayAuthorized(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var res *gatewayResponse
+	err = c.withFailover(r.Context(), r.Method, func(endpoint Endpoint) (err error) {
+		res, err = c.sendGatewayRequest(endpoint, r, body)
+		return err
+	})
+	if res == nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		writeGatewayError(w, res, apiErr)
+		return
+	}
+
+	for k, v := range res.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(res.status)
+	w.Write(res.body)
+}
+
+// gatewayAuthorized reports whether r carries the gateway secret,
+// go-proxmox sends it as the secret of its api token authorization
+// (PVEAPIToken=user!name=secret).
+func (c *Client) gatewayAuthorized(r *http.Request) bool {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "PVEAPIToken=")
+	if !ok {
+		return false
+	}
+	secret := auth[strings.LastIndex(auth, "=")+1:]
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(c.gatewaySecret)) == 1
+}
+
+// writeGatewayError relays an error response with the proxmox
+// reason and the parameter errors in its status line, as go-proxmox
+// only reports the status of the failed calls. net/http only
+// writes the standard reasons, the response is written on the
+// hijacked connection instead.
+func writeGatewayError(w http.ResponseWriter, res *gatewayResponse, apiErr *APIError) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		w.WriteHeader(res.status)
+		w.Write(res.body)
+		return
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(buf, "HTTP/1.1 %d %s\r\n", res.status, gatewayReason(apiErr))
+	for k, values := range res.header {
+		for _, v := range values {
+			fmt.Fprintf(buf, "%s: %s\r\n", k, v)
+		}
+	}
+	fmt.Fprintf(buf, "Content-Length: %d\r\nConnection: close\r\n\r\n", len(res.body))
+	buf.Write(res.body)
+	buf.Flush()
+}
+
+// gatewayReason formats the reason and the parameter errors of
+// apiErr on a single line, i.e.
+// "Parameter verification failed. (vmid: invalid format)".
+func gatewayReason(apiErr *APIError) string {
+	reason := apiErr.Message
+	if reason == "" {
+		reason = http.StatusText(apiErr.StatusCode)
+	}
+
+	keys := make([]string, 0, len(apiErr.Errors))
+	for k := range apiErr.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	details := []string{}
+	for _, k := range keys {
+		details = append(details, fmt.Sprintf("%s: %s", k, strings.TrimSpace(apiErr.Errors[k])))
+	}
+	if len(details) > 0 {
+		reason = fmt.Sprintf("%s (%s)", reason, strings.Join(details, "; "))
+	}
+	return strings.Join(strings.Fields(reason), " ")
+}
+
+// sendGatewayRequest sends the go-proxmox request r to endpoint,
+// the response is returned along with the api error of the error
+// statuses so they are handled as the client ones.
+func (c *Client) sendGatewayRequest(endpoint Endpoint, r *http.Request, body []byte) (*gatewayResponse, error) {
+	path := strings.TrimPrefix(r.URL.Path, "/api2/json")
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, "https://"+endpoint.String()+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range gatewayHeaders {
+		if v := r.Header.Values(k); len(v) > 0 {
+			req.Header[k] = v
+		}
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	if err := c.authenticate(req); err != nil {
+		return nil, err
+	}
+
+	// the calls are logged as the client ones, with the query and
+	// form params.
+	params := r.URL.Query()
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k, v := range form {
+				params[k] = append(params[k], v...)
+			}
+		}
+	}
+
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		logAPICall(c.logCtx, apiLog{method: r.Method, path: path, params: params, duration: time.Since(start), err: err})
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		logAPICall(c.logCtx, apiLog{method: r.Method, path: path, params: params, status: res.StatusCode, duration: time.Since(start), err: err})
+		return nil, err
+	}
+
+	gwRes := &gatewayResponse{status: res.StatusCode, header: http.Header{}, body: b}
+	if v := res.Header.Values("Content-Type"); len(v) > 0 {
+		gwRes.header["Content-Type"] = v
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := newAPIError(r.Method, path, res, b)
+		logAPICall(c.logCtx, apiLog{method: r.Method, path: path, params: params, status: res.StatusCode, duration: time.Since(start), body: b, err: apiErr})
+		return gwRes, apiErr
+	}
+	logAPICall(c.logCtx, apiLog{method: r.Method, path: path, params: params, status: res.StatusCode, duration: time.Since(start), body: b})
+	return gwRes, nil
+}
+
+// gatewayCertificate generates the self-signed certificate of the
+// gateway, go-proxmox skips its verification as it only connects
+// to the loopback address.
+func gatewayCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "terraform-provider-proxmox gateway"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour * 24 * 365),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,39 @@
+package proxmox
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGatewayAuthorized(t *testing.T) {
+	c := &Client{gatewaySecret: "0123456789abcdef"}
+
+	tests := []struct {
+		name string
+		auth string
+		want bool
+	}{
+		{"secret", "PVEAPIToken=root@pam!terraform=0123456789abcdef", true},
+		{"secret without user", "PVEAPIToken=!=0123456789abcdef", true},
+		{"other secret", "PVEAPIToken=root@pam!terraform=fedcba9876543210", false},
+		{"secret prefix", "PVEAPIToken=root@pam!terraform=0123456789", false},
+		{"api token", "PVEAPIToken=root@pam!terraform=6f1c3f2a-6f0e-4a4e-9d43-2f4b1b7d4f5e", false},
+		{"ticket", "PVEAuthCookie=0123456789abcdef", false},
+		{"missing", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "https://127.0.0.1/api2/json/version", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			if got := c.gatewayAuthorized(r); got != tt.want {
+				t.Errorf("gatewayAuthorized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
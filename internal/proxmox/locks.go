@@ -0,0 +1,113 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// lockErrorRe matches the errors proxmox returns when a guest or
+// its config is locked by another operation, i.e.
+// "CT 100 is locked (clone)" or
+// "can't lock file '/run/lock/lxc/pve-config-100.lock' - got timeout".
+var lockErrorRe = regexp.MustCompile(`is locked|can't lock file|got timeout`)
+
+// IsLockError reports whether err is caused by a proxmox lock.
+func IsLockError(err error) bool {
+	return err != nil && lockErrorRe.MatchString(err.Error())
+}
+
+// GuestKey returns the Locks key of a guest.
+func GuestKey(vmid int) string {
+	return fmt.Sprintf("guest/%d", vmid)
+}
+
+// Locks serializes the conflicting operations run by the
+// provider, as terraform applies the resources in parallel.
+//
+// Operations hold keys (i.e. a guest or the clone source) that
+// are locked exclusively, and a slot of their node when a node
+// concurrency limit is set.
+type Locks struct {
+	nodeLimit int
+
+	mu    sync.Mutex
+	keys  map[string]chan struct{}
+	nodes map[string]chan struct{}
+}
+
+// NewLocks creates a lock manager, nodeLimit bounds the number of
+// concurrent operations per node, 0 means unlimited.
+func NewLocks(nodeLimit int) *Locks {
+	return &Locks{
+		nodeLimit: nodeLimit,
+		keys:      map[string]chan struct{}{},
+		nodes:     map[string]chan struct{}{},
+	}
+}
+
+// Acquire locks the given keys and a slot of node (if not empty).
+// It blocks until they are all available or ctx is done, the
+// returned release func must be called once the operation is done.
+func (l *Locks) Acquire(ctx context.Context, node string, keys ...string) (func(), error) {
+	// keys are locked in order so two operations sharing
+	// several keys cannot deadlock.
+	keys = append([]string{}, keys...)
+	sort.Strings(keys)
+
+	sems := []chan struct{}{}
+	for i, key := range keys {
+		if i > 0 && keys[i-1] == key {
+			continue
+		}
+		sems = append(sems, l.sem(l.keys, key, 1))
+	}
+	if node != "" && l.nodeLimit > 0 {
+		sems = append(sems, l.sem(l.nodes, node, l.nodeLimit))
+	}
+
+	acquired := []chan struct{}{}
+	release := func() {
+		for i := len(acquired) - 1; i >= 0; i-- {
+			<-acquired[i]
+		}
+	}
+	for _, sem := range sems {
+		select {
+		case sem <- struct{}{}:
+			acquired = append(acquired, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// Do runs fn holding the given node slot and keys.
+func (l *Locks) Do(ctx context.Context, node string, keys []string, fn func() error) error {
+	release, err := l.Acquire(ctx, node, keys...)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return fn()
+}
+
+// sem returns the semaphore of name within sems, creating it
+// with the given size when missing.
+func (l *Locks) sem(sems map[string]chan struct{}, name string, size int) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sem, ok := sems[name]
+	if !ok {
+		sem = make(chan struct{}, size)
+		sems[name] = sem
+	}
+	return sem
+}
@@ -0,0 +1,112 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsLockError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"guest locked", errors.New("CT 100 is locked (clone)"), true},
+		{"config lock timeout", errors.New("can't lock file '/run/lock/lxc/pve-config-100.lock' - got timeout"), true},
+		{"timeout", errors.New("got timeout"), true},
+		{"other error", errors.New("CT 100 already exists"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLockError(tt.err); got != tt.want {
+				t.Errorf("IsLockError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeLimit int
+		node      string
+		keys      [][]string
+		// maximum number of operations expected to run at once.
+		want int32
+	}{
+		{name: "same key", keys: [][]string{{"guest/100"}, {"guest/100"}, {"guest/100"}}, want: 1},
+		{name: "distinct keys", keys: [][]string{{"guest/100"}, {"guest/101"}, {"guest/102"}}, want: 3},
+		{name: "shared key", keys: [][]string{{"guest/100", "guest/200"}, {"guest/200", "guest/101"}}, want: 1},
+		{name: "duplicated key", keys: [][]string{{"guest/100", "guest/100"}}, want: 1},
+		{name: "node limit", nodeLimit: 2, node: "pve1", keys: [][]string{{"guest/100"}, {"guest/101"}, {"guest/102"}, {"guest/103"}}, want: 2},
+		{name: "no node", nodeLimit: 1, keys: [][]string{{"guest/100"}, {"guest/101"}}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLocks(tt.nodeLimit)
+
+			var running, peak atomic.Int32
+			start := make(chan struct{})
+			wg := sync.WaitGroup{}
+			for _, keys := range tt.keys {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					err := l.Do(context.Background(), tt.node, keys, func() error {
+						n := running.Add(1)
+						for {
+							m := peak.Load()
+							if n <= m || peak.CompareAndSwap(m, n) {
+								break
+							}
+						}
+						time.Sleep(time.Millisecond * 50)
+						running.Add(-1)
+						return nil
+					})
+					if err != nil {
+						t.Errorf("Do() error = %v", err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			if got := peak.Load(); got != tt.want {
+				t.Errorf("concurrent operations = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocksCanceled(t *testing.T) {
+	l := NewLocks(0)
+	release, err := l.Acquire(context.Background(), "", "guest/100", "guest/101")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if _, err := l.Acquire(ctx, "", "guest/101"); err != context.DeadlineExceeded {
+		t.Fatalf("Acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the canceled acquisition must not hold guest/100.
+	release()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = l.Acquire(ctx, "", "guest/100", "guest/101")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	release()
+}
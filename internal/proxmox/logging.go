@@ -0,0 +1,120 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem of the api calls, its level
+// can be set apart with the TF_LOG_PROVIDER_PROXMOX_API env var.
+const LogSubsystem = "proxmox_api"
+
+// redacted replaces the sensitive values in the logs.
+const redacted = "***"
+
+// sensitiveKeyRe matches the params and response fields whose
+// values are masked in the logs, i.e. password, token, ticket,
+// CSRFPreventionToken or the lxc cmds.
+var sensitiveKeyRe = regexp.MustCompile(`(?i)pass|token|ticket|secret|otp|tfa|private|cmd|command`)
+
+// IsSensitiveKey reports whether the values of key are masked in
+// the logs.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeyRe.MatchString(key)
+}
+
+// apiLog describes an api call for the logs.
+type apiLog struct {
+	method   string
+	path     string
+	params   url.Values
+	status   int
+	duration time.Duration
+	body     []byte
+	err      error
+}
+
+// logAPICall logs the api call at the debug level of the proxmox_api
+// subsystem, the response body is only logged at the trace level.
+// Sensitive params and response fields are masked.
+func logAPICall(ctx context.Context, call apiLog) {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_PROXMOX_API"))
+
+	fields := map[string]any{
+		"method":      call.method,
+		"path":        call.path,
+		"duration_ms": call.duration.Milliseconds(),
+	}
+	if len(call.params) > 0 {
+		fields["params"] = redactParams(call.params)
+	}
+	if call.status != 0 {
+		fields["status"] = call.status
+	}
+	if call.err != nil {
+		fields["error"] = call.err.Error()
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Proxmox API call", fields)
+
+	if len(call.body) > 0 {
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Proxmox API response", map[string]any{
+			"method":        call.method,
+			"path":          call.path,
+			"response_body": string(redactJSON(call.body)),
+		})
+	}
+}
+
+// redactParams returns the params to log, with the sensitive values
+// masked.
+func redactParams(params url.Values) map[string]any {
+	out := map[string]any{}
+	for k, values := range params {
+		switch {
+		case IsSensitiveKey(k):
+			out[k] = redacted
+		case len(values) == 1:
+			out[k] = values[0]
+		default:
+			out[k] = values
+		}
+	}
+	return out
+}
+
+// redactJSON masks the sensitive fields of a json body, bodies that
+// are not json are returned as-is.
+func redactJSON(body []byte) []byte {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if IsSensitiveKey(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,50 @@
+package proxmox
+
+import "testing"
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "ticket response",
+			body: `{"data":{"username":"root@pam","ticket":"PVE:root@pam:abc","CSRFPreventionToken":"xyz"}}`,
+			want: `{"data":{"CSRFPreventionToken":"***","ticket":"***","username":"root@pam"}}`,
+		},
+		{
+			name: "nested list",
+			body: `{"data":[{"id":"a","password":"secret"},{"id":"b","nested":{"token":"t"}}]}`,
+			want: `{"data":[{"id":"a","password":"***"},{"id":"b","nested":{"token":"***"}}]}`,
+		},
+		{
+			name: "sensitive object",
+			body: `{"private":{"key":"value"}}`,
+			want: `{"private":"***"}`,
+		},
+		{
+			name: "nothing sensitive",
+			body: `{"data":{"vmid":100,"status":"running"}}`,
+			want: `{"data":{"status":"running","vmid":100}}`,
+		},
+		{
+			name: "not json",
+			body: `ticket=abc`,
+			want: `ticket=abc`,
+		},
+		{
+			name: "null data",
+			body: `{"data":null}`,
+			want: `{"data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.body))); got != tt.want {
+				t.Errorf("redactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// LXCConfig maps the GET /nodes/{node}/lxc/{vmid}/config response
+// data. Values are kept as strings as the keys are dynamic, i.e.
+// net0, net1 or mp0.
+type LXCConfig map[string]string
+
+func (cfg *LXCConfig) UnmarshalJSON(b []byte) error {
+	raw := map[string]any{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*cfg = LXCConfig{}
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			(*cfg)[k] = v
+		case float64:
+			(*cfg)[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			(*cfg)[k] = "0"
+			if v {
+				(*cfg)[k] = "1"
+			}
+		case nil:
+		default:
+			return fmt.Errorf("unexpected lxc config %s value %v", k, v)
+		}
+	}
+
+	return nil
+}
+
+// LXCStatus maps the
+// GET /nodes/{node}/lxc/{vmid}/status/current response data.
+type LXCStatus struct {
+	VMID   Int    `json:"vmid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Uptime int64  `json:"uptime"`
+}
+
+// LXCInterface maps the
+// GET /nodes/{node}/lxc/{vmid}/interfaces response data.
+type LXCInterface struct {
+	Name   string `json:"name"`
+	HWAddr string `json:"hwaddr"`
+	Inet   string `json:"inet"`
+	Inet6  string `json:"inet6"`
+}
+
+// LXCConfigRequest maps the PUT /nodes/{node}/lxc/{vmid}/config
+// params that are not supported by go-proxmox.
+type LXCConfigRequest struct {
+	Node       string   `url:"-"`
+	VMID       int      `url:"-"`
+	Hookscript *string  `url:"hookscript"`
+	Delete     []string `url:"delete,omitempty"`
+}
+
+func lxcPath(node string, vmid int) string {
+	return fmt.Sprintf("/nodes/%s/lxc/%d", url.PathEscape(node), vmid)
+}
+
+// GetLXCConfig retrieves the config of a container.
+func (c *Client) GetLXCConfig(ctx context.Context, node string, vmid int) (LXCConfig, error) {
+	res := LXCConfig{}
+	if err := c.Get(ctx, lxcPath(node, vmid)+"/config", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateLXCConfig updates the config of a container.
+func (c *Client) UpdateLXCConfig(ctx context.Context, req LXCConfigRequest) error {
+	return c.Put(ctx, lxcPath(req.Node, req.VMID)+"/config", EncodeParams(req), nil)
+}
+
+// GetLXCStatus retrieves the current status of a container.
+func (c *Client) GetLXCStatus(ctx context.Context, node string, vmid int) (*LXCStatus, error) {
+	res := &LXCStatus{}
+	if err := c.Get(ctx, lxcPath(node, vmid)+"/status/current", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetLXCInterfaces lists the interfaces of a running container.
+func (c *Client) GetLXCInterfaces(ctx context.Context, node string, vmid int) ([]LXCInterface, error) {
+	res := []LXCInterface{}
+	if err := c.Get(ctx, lxcPath(node, vmid)+"/interfaces", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,84 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NodeFirewallRule maps a rule of the
+// GET /nodes/{node}/firewall/rules response data.
+//
+// Unlike the go-proxmox rules, the comment is returned as is,
+// which allows handling rules that were not created through
+// go-proxmox.
+type NodeFirewallRule struct {
+	Pos       int    `json:"pos"`
+	Action    string `json:"action"`
+	Type      string `json:"type"`
+	Comment   string `json:"comment"`
+	Dest      string `json:"dest"`
+	Dport     string `json:"dport"`
+	Enable    int    `json:"enable"`
+	ICMPType  string `json:"icmp-type"`
+	Iface     string `json:"iface"`
+	IPVersion int    `json:"ipversion"`
+	Log       string `json:"log"`
+	Macro     string `json:"macro"`
+	Proto     string `json:"proto"`
+	Source    string `json:"source"`
+	Sport     string `json:"sport"`
+	Digest    string `json:"digest"`
+}
+
+// UpdateNodeFirewallRuleRequest maps the
+// PUT /nodes/{node}/firewall/rules/{pos} parameters.
+type UpdateNodeFirewallRuleRequest struct {
+	Node     string   `url:"-"`
+	Pos      int      `url:"-"`
+	Delete   []string `url:"delete,omitempty"`
+	Action   *string  `url:"action"`
+	Type     *string  `url:"type"`
+	Comment  *string  `url:"comment"`
+	Dest     *string  `url:"dest"`
+	Dport    *string  `url:"dport"`
+	Enable   *bool    `url:"enable"`
+	ICMPType *string  `url:"icmp-type"`
+	Iface    *string  `url:"iface"`
+	Log      *string  `url:"log"`
+	Macro    *string  `url:"macro"`
+	Proto    *string  `url:"proto"`
+	Source   *string  `url:"source"`
+	Sport    *string  `url:"sport"`
+	Digest   string   `url:"digest,omitempty"`
+}
+
+// GetNodeFirewallRules lists the node firewall rules
+// ordered by position.
+func (c *Client) GetNodeFirewallRules(ctx context.Context, node string) ([]NodeFirewallRule, error) {
+	res := []NodeFirewallRule{}
+	p := fmt.Sprintf("/nodes/%s/firewall/rules", url.PathEscape(node))
+	if err := c.Get(ctx, p, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateNodeFirewallRule modifies the rule at the given
+// position.
+func (c *Client) UpdateNodeFirewallRule(ctx context.Context, req UpdateNodeFirewallRuleRequest) error {
+	p := fmt.Sprintf("/nodes/%s/firewall/rules/%d", url.PathEscape(req.Node), req.Pos)
+	return c.Put(ctx, p, EncodeParams(req), nil)
+}
+
+// DeleteNodeFirewallRule deletes the rule at the given
+// position. When digest is set, the rule is only deleted if the
+// rules were not changed since they were read.
+func (c *Client) DeleteNodeFirewallRule(ctx context.Context, node string, pos int, digest string) error {
+	p := fmt.Sprintf("/nodes/%s/firewall/rules/%d", url.PathEscape(node), pos)
+	params := url.Values{}
+	if digest != "" {
+		params.Set("digest", digest)
+	}
+	return c.Delete(ctx, p, params, nil)
+}
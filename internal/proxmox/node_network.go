@@ -0,0 +1,142 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NodeNetwork maps the
+// GET /nodes/{node}/network/{iface} response data.
+//
+// Values are read from /etc/network/interfaces(.new), which
+// means the pending changes are returned when there are any.
+type NodeNetwork struct {
+	Iface              string `json:"iface"`
+	Type               string `json:"type"`
+	Active             Int    `json:"active"`
+	Autostart          Int    `json:"autostart"`
+	BondMode           string `json:"bond_mode"`
+	BondPrimary        string `json:"bond-primary"`
+	BondXmitHashPolicy string `json:"bond_xmit_hash_policy"`
+	BridgePorts        string `json:"bridge_ports"`
+	BridgeVlanAware    Int    `json:"bridge_vlan_aware"`
+	CIDR               string `json:"cidr"`
+	CIDR6              string `json:"cidr6"`
+	Comments           string `json:"comments"`
+	Gateway            string `json:"gateway"`
+	Gateway6           string `json:"gateway6"`
+	MTU                Int    `json:"mtu"`
+	OVSBonds           string `json:"ovs_bonds"`
+	OVSBridge          string `json:"ovs_bridge"`
+	OVSOptions         string `json:"ovs_options"`
+	OVSPorts           string `json:"ovs_ports"`
+	OVSTag             Int    `json:"ovs_tag"`
+	Slaves             string `json:"slaves"`
+	VlanID             Int    `json:"vlan-id"`
+	VlanRawDevice      string `json:"vlan-raw-device"`
+}
+
+// NodeNetworkRequest maps the POST /nodes/{node}/network and
+// PUT /nodes/{node}/network/{iface} parameters.
+type NodeNetworkRequest struct {
+	Node               string   `url:"-"`
+	Iface              string   `url:"iface"`
+	Type               string   `url:"type"`
+	Delete             []string `url:"delete,omitempty"`
+	Autostart          *bool    `url:"autostart"`
+	BondMode           *string  `url:"bond_mode"`
+	BondPrimary        *string  `url:"bond-primary"`
+	BondXmitHashPolicy *string  `url:"bond_xmit_hash_policy"`
+	BridgePorts        *string  `url:"bridge_ports"`
+	BridgeVlanAware    *bool    `url:"bridge_vlan_aware"`
+	CIDR               *string  `url:"cidr"`
+	CIDR6              *string  `url:"cidr6"`
+	Comments           *string  `url:"comments"`
+	Gateway            *string  `url:"gateway"`
+	Gateway6           *string  `url:"gateway6"`
+	MTU                *int64   `url:"mtu"`
+	OVSBonds           *string  `url:"ovs_bonds"`
+	OVSBridge          *string  `url:"ovs_bridge"`
+	OVSOptions         *string  `url:"ovs_options"`
+	OVSPorts           *string  `url:"ovs_ports"`
+	OVSTag             *int64   `url:"ovs_tag"`
+	Slaves             *string  `url:"slaves"`
+	VlanID             *int64   `url:"vlan-id"`
+	VlanRawDevice      *string  `url:"vlan-raw-device"`
+}
+
+// nodeNetworkList maps the GET /nodes/{node}/network response,
+// the pending changes diff is returned next to the data.
+type nodeNetworkList struct {
+	Changes string `json:"changes"`
+}
+
+func (*nodeNetworkList) envelope() {}
+
+// GetNodeNetworkChanges returns the diff of the node pending
+// network changes, it is empty when there are none.
+func (c *Client) GetNodeNetworkChanges(ctx context.Context, node string) (string, error) {
+	res := &nodeNetworkList{}
+	p := fmt.Sprintf("/nodes/%s/network", url.PathEscape(node))
+	if err := c.Get(ctx, p, nil, res); err != nil {
+		return "", err
+	}
+	return res.Changes, nil
+}
+
+// GetNodeNetwork retrieves the network interface config.
+func (c *Client) GetNodeNetwork(ctx context.Context, node, iface string) (*NodeNetwork, error) {
+	res := &NodeNetwork{}
+	p := fmt.Sprintf("/nodes/%s/network/%s", url.PathEscape(node), url.PathEscape(iface))
+	if err := c.Get(ctx, p, nil, res); err != nil {
+		return nil, err
+	}
+	res.Iface = iface
+	return res, nil
+}
+
+// CreateNodeNetwork creates a network interface. The change
+// remains pending until ApplyNodeNetwork is called.
+func (c *Client) CreateNodeNetwork(ctx context.Context, req NodeNetworkRequest) error {
+	p := fmt.Sprintf("/nodes/%s/network", url.PathEscape(req.Node))
+	params := EncodeParams(req)
+	params.Del("delete")
+	return c.Post(ctx, p, params, nil)
+}
+
+// UpdateNodeNetwork updates a network interface. The change
+// remains pending until ApplyNodeNetwork is called.
+func (c *Client) UpdateNodeNetwork(ctx context.Context, req NodeNetworkRequest) error {
+	p := fmt.Sprintf("/nodes/%s/network/%s", url.PathEscape(req.Node), url.PathEscape(req.Iface))
+	params := EncodeParams(req)
+	params.Del("iface")
+	return c.Put(ctx, p, params, nil)
+}
+
+// DeleteNodeNetwork deletes a network interface. The change
+// remains pending until ApplyNodeNetwork is called.
+func (c *Client) DeleteNodeNetwork(ctx context.Context, node, iface string) error {
+	p := fmt.Sprintf("/nodes/%s/network/%s", url.PathEscape(node), url.PathEscape(iface))
+	return c.Delete(ctx, p, nil, nil)
+}
+
+// ApplyNodeNetwork reloads the node network config, applying
+// the pending changes, and waits for the reload task.
+func (c *Client) ApplyNodeNetwork(ctx context.Context, node string) error {
+	var upid string
+	p := fmt.Sprintf("/nodes/%s/network", url.PathEscape(node))
+	if err := c.Put(ctx, p, nil, &upid); err != nil {
+		return err
+	}
+	if upid == "" {
+		return nil
+	}
+	return c.WaitTask(ctx, upid)
+}
+
+// RevertNodeNetwork discards the node pending network changes.
+func (c *Client) RevertNodeNetwork(ctx context.Context, node string) error {
+	p := fmt.Sprintf("/nodes/%s/network", url.PathEscape(node))
+	return c.Delete(ctx, p, nil, nil)
+}
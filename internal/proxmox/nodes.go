@@ -0,0 +1,71 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Node maps the GET /nodes response data.
+type Node struct {
+	Node           string  `json:"node"`
+	Status         string  `json:"status"`
+	CPU            float64 `json:"cpu"`
+	MaxCPU         int64   `json:"maxcpu"`
+	Mem            int64   `json:"mem"`
+	MaxMem         int64   `json:"maxmem"`
+	Disk           int64   `json:"disk"`
+	MaxDisk        int64   `json:"maxdisk"`
+	Uptime         int64   `json:"uptime"`
+	SSLFingerprint string  `json:"ssl_fingerprint"`
+}
+
+// NodeUsage maps the memory, swap and rootfs usage of a node.
+type NodeUsage struct {
+	Total int64 `json:"total"`
+	Used  int64 `json:"used"`
+	Free  int64 `json:"free"`
+	Avail int64 `json:"avail"`
+}
+
+// NodeStatus maps the GET /nodes/{node}/status response data.
+type NodeStatus struct {
+	KVersion      string `json:"kversion"`
+	PVEVersion    string `json:"pveversion"`
+	CurrentKernel struct {
+		Release string `json:"release"`
+		Machine string `json:"machine"`
+	} `json:"current-kernel"`
+	LoadAvg []string  `json:"loadavg"`
+	CPU     float64   `json:"cpu"`
+	Wait    float64   `json:"wait"`
+	Uptime  int64     `json:"uptime"`
+	Memory  NodeUsage `json:"memory"`
+	Swap    NodeUsage `json:"swap"`
+	RootFS  NodeUsage `json:"rootfs"`
+	CPUInfo struct {
+		Model   string `json:"model"`
+		CPUs    int64  `json:"cpus"`
+		Sockets int64  `json:"sockets"`
+		Cores   int64  `json:"cores"`
+		MHz     string `json:"mhz"`
+	} `json:"cpuinfo"`
+}
+
+// GetNodes lists the cluster nodes.
+func (c *Client) GetNodes(ctx context.Context) ([]Node, error) {
+	res := []Node{}
+	if err := c.Get(ctx, "/nodes", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetNodeStatus retrieves the status of a node.
+func (c *Client) GetNodeStatus(ctx context.Context, node string) (*NodeStatus, error) {
+	res := &NodeStatus{}
+	if err := c.Get(ctx, fmt.Sprintf("/nodes/%s/status", url.PathEscape(node)), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
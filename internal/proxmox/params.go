@@ -0,0 +1,81 @@
+package proxmox
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EncodeParams encodes a request struct into url values using
+// the `url:"name[,omitempty]"` field tags.
+//
+//   - nil pointers are always skipped.
+//   - bool values are encoded as 1 or 0.
+//   - string slices are encoded as a comma separated list.
+//   - omitempty skips zero values.
+func EncodeParams(req any) url.Values {
+	values := url.Values{}
+
+	v := reflect.ValueOf(req)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("url")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		omitEmpty := opts == "omitempty"
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			values.Set(name, fv.String())
+		case reflect.Bool:
+			if fv.Bool() {
+				values.Set(name, "1")
+			} else {
+				values.Set(name, "0")
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values.Set(name, strconv.FormatInt(fv.Int(), 10))
+		case reflect.Float32, reflect.Float64:
+			values.Set(name, strconv.FormatFloat(fv.Float(), 'f', -1, 64))
+		case reflect.Slice:
+			items := []string{}
+			for j := 0; j < fv.Len(); j++ {
+				items = append(items, fmt.Sprint(fv.Index(j).Interface()))
+			}
+			if omitEmpty && len(items) == 0 {
+				continue
+			}
+			values.Set(name, strings.Join(items, ","))
+		default:
+			values.Set(name, fmt.Sprint(fv.Interface()))
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,93 @@
+package proxmox
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeParams(t *testing.T) {
+	str := "value"
+	empty := ""
+	yes := true
+	no := false
+	n := int64(42)
+
+	type request struct {
+		Skipped   string `url:"-"`
+		Untagged  string
+		String    string   `url:"string"`
+		OmitEmpty string   `url:"omit,omitempty"`
+		Pointer   *string  `url:"pointer"`
+		Bool      bool     `url:"bool"`
+		BoolPtr   *bool    `url:"bool_ptr"`
+		Int       int      `url:"int,omitempty"`
+		IntPtr    *int64   `url:"int_ptr"`
+		Float     float64  `url:"float,omitempty"`
+		List      []string `url:"list,omitempty"`
+		Ints      []int    `url:"ints"`
+	}
+
+	tests := []struct {
+		name string
+		req  any
+		want url.Values
+	}{
+		{
+			name: "zero values",
+			req:  request{},
+			want: url.Values{"string": {""}, "bool": {"0"}, "ints": {""}},
+		},
+		{
+			name: "values",
+			req: request{
+				Skipped:   "skipped",
+				Untagged:  "untagged",
+				String:    "a b",
+				OmitEmpty: "set",
+				Bool:      true,
+				Int:       -3,
+				Float:     1.5,
+				List:      []string{"a", "b"},
+				Ints:      []int{1, 2},
+			},
+			want: url.Values{
+				"string": {"a b"},
+				"omit":   {"set"},
+				"bool":   {"1"},
+				"int":    {"-3"},
+				"float":  {"1.5"},
+				"list":   {"a,b"},
+				"ints":   {"1,2"},
+			},
+		},
+		{
+			name: "pointers are sent even when empty",
+			req:  &request{Pointer: &empty, BoolPtr: &no, IntPtr: &n},
+			want: url.Values{"string": {""}, "pointer": {""}, "bool": {"0"}, "bool_ptr": {"0"}, "int_ptr": {"42"}, "ints": {""}},
+		},
+		{
+			name: "pointer values",
+			req:  request{Pointer: &str, BoolPtr: &yes},
+			want: url.Values{"string": {""}, "pointer": {"value"}, "bool": {"0"}, "bool_ptr": {"1"}, "ints": {""}},
+		},
+		{
+			name: "nil pointer request",
+			req:  (*request)(nil),
+			want: url.Values{},
+		},
+		{
+			name: "not a struct",
+			req:  "value",
+			want: url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodeParams(tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EncodeParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Permissions maps the GET /access/permissions response data, the
+// privileges of the authenticated user (or token) per acl path.
+// A privilege value of 1 means it propagates to the child paths.
+type Permissions map[string]map[string]int
+
+// GetPermissions retrieves the effective permissions of the
+// authenticated user, or of the api token when one is used.
+func (c *Client) GetPermissions(ctx context.Context) (Permissions, error) {
+	res := Permissions{}
+	if err := c.Get(ctx, "/access/permissions", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Has reports whether privilege is granted on aclPath (i.e.
+// /vms/100), either on the path itself or propagated from the
+// closest parent path listed in the permissions.
+func (p Permissions) Has(aclPath, privilege string) bool {
+	aclPath = "/" + strings.Trim(aclPath, "/")
+	for current := aclPath; ; current = parentACLPath(current) {
+		if privileges, ok := p[current]; ok {
+			propagate, granted := privileges[privilege]
+			return granted && (current == aclPath || propagate == 1)
+		}
+		if current == "/" {
+			return false
+		}
+	}
+}
+
+func parentACLPath(aclPath string) string {
+	i := strings.LastIndex(aclPath, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return aclPath[:i]
+}
+
+// Preflight checks the api connectivity and credentials by
+// reading the api version and the permissions of the user, which
+// are then returned by Permissions.
+func (c *Client) Preflight(ctx context.Context) (*Version, error) {
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the api version: %w", err)
+	}
+
+	permissions, err := c.GetPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the user permissions: %w", err)
+	}
+	c.permissions = permissions
+	return version, nil
+}
+
+// Permissions returns the permissions read by Preflight, nil when
+// the preflight is disabled.
+func (c *Client) Permissions() Permissions {
+	return c.permissions
+}
@@ -0,0 +1,160 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how the failed api calls are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a call is sent, 1
+	// disables the retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it is doubled
+	// on each retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Jitter randomizes the delays by up to this fraction of
+	// them, from 0 to 1.
+	Jitter float64
+	// Statuses are the retryable http statuses. They are not
+	// retried for POST calls, as those are not idempotent.
+	Statuses []int
+	// LockErrors retries the calls failing because a guest or a
+	// config file is locked.
+	LockErrors bool
+}
+
+// DefaultRetryPolicy is the policy used when none is configured.
+// The 500 status is not retried by default as proxmox uses it for
+// most errors, i.e. an invalid parameter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     time.Second * 2,
+	MaxBackoff:  time.Second * 30,
+	Jitter:      0.2,
+	Statuses:    []int{502, 503, 596},
+	LockErrors:  true,
+}
+
+// statusRe matches the http statuses within the go-proxmox errors,
+// which do not expose them, i.e. "status code 503" or
+// "503 Service Unavailable".
+var statusRe = regexp.MustCompile(`(?i:status(?: code)?:? )(\d{3})\b|\b(\d{3}) [A-Z][a-z]`)
+
+// Retry runs fn, a call to the api, according to the client rate
+// limit and retry policy.
+func (c *Client) Retry(ctx context.Context, fn func() error) error {
+	return c.retry(ctx, true, fn)
+}
+
+// RetryLocked runs fn according to the client rate limit, fn is
+// only retried on lock errors. It is used for the calls that are
+// not idempotent, i.e. a guest creation.
+func (c *Client) RetryLocked(ctx context.Context, fn func() error) error {
+	return c.retry(ctx, false, fn)
+}
+
+func (c *Client) retry(ctx context.Context, idempotent bool, fn func() error) error {
+	policy := c.retryPolicy
+	delay := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+
+		err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(idempotent, err) {
+			return err
+		}
+
+		wait := delay
+		if policy.Jitter > 0 {
+			wait += time.Duration((rand.Float64()*2 - 1) * policy.Jitter * float64(delay))
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+
+		delay *= 2
+		if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+			delay = policy.MaxBackoff
+		}
+	}
+}
+
+// retryable reports whether err is worth retrying.
+func (p RetryPolicy) retryable(idempotent bool, err error) bool {
+	if p.LockErrors && IsLockError(err) {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		return slices.Contains(p.Statuses, apiErr.StatusCode)
+	}
+
+	for _, match := range statusRe.FindAllStringSubmatch(err.Error(), -1) {
+		status, _ := strconv.Atoi(match[1] + match[2])
+		if slices.Contains(p.Statuses, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimiter spaces the api calls by interval, a nil limiter
+// does not limit them.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// wait blocks until a call can be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	at := l.next
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package proxmox
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStatusRe(t *testing.T) {
+	tests := []struct {
+		err  string
+		want []string
+	}{
+		{"request failed with status code 503", []string{"503"}},
+		{"unexpected status: 502", []string{"502"}},
+		{"Status 596 received", []string{"596"}},
+		{"503 Service Unavailable", []string{"503"}},
+		{"got 500 Internal Server Error after 404 Not Found", []string{"500", "404"}},
+		{"CT 100 is locked (clone)", nil},
+		{"status code 5030", nil},
+		{"dial tcp 10.0.0.1:8006: connection refused", nil},
+		{"503 service unavailable", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			got := []string{}
+			for _, match := range statusRe.FindAllStringSubmatch(tt.err, -1) {
+				got = append(got, match[1]+match[2])
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("statuses = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("statuses = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestsPerSecond float64
+		calls             int
+		min               time.Duration
+		max               time.Duration
+	}{
+		{name: "unlimited", requestsPerSecond: 0, calls: 10, max: time.Millisecond * 20},
+		{name: "first call not delayed", requestsPerSecond: 1, calls: 1, max: time.Millisecond * 20},
+		{name: "calls spaced", requestsPerSecond: 50, calls: 6, min: time.Millisecond * 100, max: time.Millisecond * 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.requestsPerSecond)
+
+			start := time.Now()
+			for i := 0; i < tt.calls; i++ {
+				if err := l.wait(context.Background()); err != nil {
+					t.Fatalf("wait() error = %v", err)
+				}
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.min || elapsed > tt.max {
+				t.Errorf("%d calls took %s, want between %s and %s", tt.calls, elapsed, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	l := newRateLimiter(0.1)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := l.wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
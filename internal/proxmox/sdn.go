@@ -0,0 +1,238 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SDNZone maps the GET /cluster/sdn/zones/{zone} response data.
+type SDNZone struct {
+	Zone         string `json:"zone"`
+	Type         string `json:"type"`
+	Bridge       string `json:"bridge"`
+	Controller   string `json:"controller"`
+	DHCP         string `json:"dhcp"`
+	ExitNodes    string `json:"exitnodes"`
+	IPAM         string `json:"ipam"`
+	MAC          string `json:"mac"`
+	MTU          Int    `json:"mtu"`
+	Nodes        string `json:"nodes"`
+	Peers        string `json:"peers"`
+	Tag          Int    `json:"tag"`
+	VlanProtocol string `json:"vlan-protocol"`
+	VrfVxlan     Int    `json:"vrf-vxlan"`
+}
+
+// SDNZoneRequest maps the POST /cluster/sdn/zones and
+// PUT /cluster/sdn/zones/{zone} parameters.
+type SDNZoneRequest struct {
+	Zone         string   `url:"zone"`
+	Type         string   `url:"type"`
+	Delete       []string `url:"delete,omitempty"`
+	Bridge       *string  `url:"bridge"`
+	Controller   *string  `url:"controller"`
+	DHCP         *string  `url:"dhcp"`
+	ExitNodes    *string  `url:"exitnodes"`
+	IPAM         *string  `url:"ipam"`
+	MAC          *string  `url:"mac"`
+	MTU          *int64   `url:"mtu"`
+	Nodes        *string  `url:"nodes"`
+	Peers        *string  `url:"peers"`
+	Tag          *int64   `url:"tag"`
+	VlanProtocol *string  `url:"vlan-protocol"`
+	VrfVxlan     *int64   `url:"vrf-vxlan"`
+}
+
+// SDNVnet maps the GET /cluster/sdn/vnets/{vnet} response data.
+type SDNVnet struct {
+	Vnet         string `json:"vnet"`
+	Zone         string `json:"zone"`
+	Alias        string `json:"alias"`
+	IsolatePorts Int    `json:"isolate-ports"`
+	Tag          Int    `json:"tag"`
+	VlanAware    Int    `json:"vlanaware"`
+}
+
+// SDNVnetRequest maps the POST /cluster/sdn/vnets and
+// PUT /cluster/sdn/vnets/{vnet} parameters.
+type SDNVnetRequest struct {
+	Vnet         string   `url:"vnet"`
+	Zone         string   `url:"zone"`
+	Delete       []string `url:"delete,omitempty"`
+	Alias        *string  `url:"alias"`
+	IsolatePorts *bool    `url:"isolate-ports"`
+	Tag          *int64   `url:"tag"`
+	VlanAware    *bool    `url:"vlanaware"`
+}
+
+// SDNDHCPRange is a subnet dhcp range.
+type SDNDHCPRange struct {
+	StartAddress string `json:"start-address"`
+	EndAddress   string `json:"end-address"`
+}
+
+// String formats the range as a proxmox property string.
+func (r SDNDHCPRange) String() string {
+	return fmt.Sprintf("start-address=%s,end-address=%s", r.StartAddress, r.EndAddress)
+}
+
+// UnmarshalJSON accepts both the object and the property
+// string representations of the range.
+func (r *SDNDHCPRange) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		type plain SDNDHCPRange
+		return json.Unmarshal(b, (*plain)(r))
+	}
+
+	for _, kv := range strings.Split(s, ",") {
+		k, v, _ := strings.Cut(kv, "=")
+		switch k {
+		case "start-address":
+			r.StartAddress = v
+		case "end-address":
+			r.EndAddress = v
+		}
+	}
+	return nil
+}
+
+// SDNSubnet maps a GET /cluster/sdn/vnets/{vnet}/subnets
+// response item.
+type SDNSubnet struct {
+	// Subnet is the proxmox subnet id, i.e. "zone-10.0.0.0-24".
+	Subnet        string         `json:"subnet"`
+	CIDR          string         `json:"cidr"`
+	Vnet          string         `json:"vnet"`
+	Zone          string         `json:"zone"`
+	Gateway       string         `json:"gateway"`
+	SNAT          Int            `json:"snat"`
+	DHCPDNSServer string         `json:"dhcp-dns-server"`
+	DHCPRange     []SDNDHCPRange `json:"dhcp-range"`
+}
+
+// SDNSubnetRequest maps the POST /cluster/sdn/vnets/{vnet}/subnets
+// and PUT /cluster/sdn/vnets/{vnet}/subnets/{subnet} parameters.
+type SDNSubnetRequest struct {
+	Vnet string `url:"-"`
+	// Subnet is the cidr on creation and the proxmox
+	// subnet id on update.
+	Subnet        string         `url:"-"`
+	Delete        []string       `url:"delete,omitempty"`
+	Gateway       *string        `url:"gateway"`
+	SNAT          *bool          `url:"snat"`
+	DHCPDNSServer *string        `url:"dhcp-dns-server"`
+	DHCPRange     []SDNDHCPRange `url:"-"`
+}
+
+func (req SDNSubnetRequest) params() url.Values {
+	params := EncodeParams(req)
+	for _, r := range req.DHCPRange {
+		params.Add("dhcp-range", r.String())
+	}
+	return params
+}
+
+// GetSDNZone retrieves the sdn zone config, including the
+// changes that were not applied yet.
+func (c *Client) GetSDNZone(ctx context.Context, zone string) (*SDNZone, error) {
+	res := &SDNZone{}
+	if err := c.Get(ctx, "/cluster/sdn/zones/"+url.PathEscape(zone), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSDNZone creates a sdn zone.
+func (c *Client) CreateSDNZone(ctx context.Context, req SDNZoneRequest) error {
+	return c.Post(ctx, "/cluster/sdn/zones", EncodeParams(req), nil)
+}
+
+// UpdateSDNZone updates a sdn zone.
+func (c *Client) UpdateSDNZone(ctx context.Context, req SDNZoneRequest) error {
+	params := EncodeParams(req)
+	params.Del("zone")
+	params.Del("type")
+	return c.Put(ctx, "/cluster/sdn/zones/"+url.PathEscape(req.Zone), params, nil)
+}
+
+// DeleteSDNZone deletes a sdn zone.
+func (c *Client) DeleteSDNZone(ctx context.Context, zone string) error {
+	return c.Delete(ctx, "/cluster/sdn/zones/"+url.PathEscape(zone), nil, nil)
+}
+
+// GetSDNVnet retrieves the sdn vnet config, including the
+// changes that were not applied yet.
+func (c *Client) GetSDNVnet(ctx context.Context, vnet string) (*SDNVnet, error) {
+	res := &SDNVnet{}
+	if err := c.Get(ctx, "/cluster/sdn/vnets/"+url.PathEscape(vnet), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSDNVnet creates a sdn vnet.
+func (c *Client) CreateSDNVnet(ctx context.Context, req SDNVnetRequest) error {
+	return c.Post(ctx, "/cluster/sdn/vnets", EncodeParams(req), nil)
+}
+
+// UpdateSDNVnet updates a sdn vnet.
+func (c *Client) UpdateSDNVnet(ctx context.Context, req SDNVnetRequest) error {
+	params := EncodeParams(req)
+	params.Del("vnet")
+	return c.Put(ctx, "/cluster/sdn/vnets/"+url.PathEscape(req.Vnet), params, nil)
+}
+
+// DeleteSDNVnet deletes a sdn vnet.
+func (c *Client) DeleteSDNVnet(ctx context.Context, vnet string) error {
+	return c.Delete(ctx, "/cluster/sdn/vnets/"+url.PathEscape(vnet), nil, nil)
+}
+
+// GetSDNSubnets lists the subnets of a sdn vnet.
+func (c *Client) GetSDNSubnets(ctx context.Context, vnet string) ([]SDNSubnet, error) {
+	res := []SDNSubnet{}
+	p := fmt.Sprintf("/cluster/sdn/vnets/%s/subnets", url.PathEscape(vnet))
+	if err := c.Get(ctx, p, nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSDNSubnet creates a sdn vnet subnet, req.Subnet being
+// the subnet cidr.
+func (c *Client) CreateSDNSubnet(ctx context.Context, req SDNSubnetRequest) error {
+	params := req.params()
+	params.Set("subnet", req.Subnet)
+	params.Set("type", "subnet")
+	p := fmt.Sprintf("/cluster/sdn/vnets/%s/subnets", url.PathEscape(req.Vnet))
+	return c.Post(ctx, p, params, nil)
+}
+
+// UpdateSDNSubnet updates a sdn vnet subnet, req.Subnet being
+// the proxmox subnet id.
+func (c *Client) UpdateSDNSubnet(ctx context.Context, req SDNSubnetRequest) error {
+	p := fmt.Sprintf("/cluster/sdn/vnets/%s/subnets/%s", url.PathEscape(req.Vnet), url.PathEscape(req.Subnet))
+	return c.Put(ctx, p, req.params(), nil)
+}
+
+// DeleteSDNSubnet deletes a sdn vnet subnet.
+func (c *Client) DeleteSDNSubnet(ctx context.Context, vnet, subnet string) error {
+	p := fmt.Sprintf("/cluster/sdn/vnets/%s/subnets/%s", url.PathEscape(vnet), url.PathEscape(subnet))
+	return c.Delete(ctx, p, nil, nil)
+}
+
+// ApplySDN applies the pending sdn changes cluster wide and
+// waits for the reload task.
+func (c *Client) ApplySDN(ctx context.Context) error {
+	var upid string
+	if err := c.Put(ctx, "/cluster/sdn", nil, &upid); err != nil {
+		return err
+	}
+	if upid == "" {
+		return nil
+	}
+	return c.WaitTask(ctx, upid)
+}
@@ -0,0 +1,112 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHTunnel configures the bastion the api is dialed through.
+type SSHTunnel struct {
+	// Host is the bastion address, host or host:port.
+	Host string
+	User string
+	// PrivateKeyPEM is the (unencrypted) private key of User.
+	PrivateKeyPEM string
+	// KnownHostsFile lists the bastion host keys.
+	KnownHostsFile string
+}
+
+// sshTunnel keeps the bastion connection, it is opened on the
+// first dial and opened again when it got closed.
+type sshTunnel struct {
+	addr   string
+	config *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+func sshTunnelDialer(cfg SSHTunnel) (dialFunc, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(cfg.PrivateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh tunnel private key: %w", err)
+	}
+
+	hostKeyCallback, err := knownhosts.New(cfg.KnownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh tunnel known hosts: %w", err)
+	}
+
+	addr := cfg.Host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	tunnel := &sshTunnel{
+		addr: addr,
+		config: &ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+		},
+	}
+	return tunnel.DialContext, nil
+}
+
+// DialContext dials addr from the bastion.
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial(network, addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	// the bastion connection may have been closed, it is
+	// opened again once.
+	t.reset(client)
+	if client, err = t.connect(ctx); err != nil {
+		return nil, err
+	}
+	return client.Dial(network, addr)
+}
+
+func (t *sshTunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, t.addr, t.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to open the ssh tunnel to %s: %w", t.addr, err)
+	}
+
+	t.client = ssh.NewClient(sshConn, chans, reqs)
+	return t.client, nil
+}
+
+func (t *sshTunnel) reset(client *ssh.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == client {
+		t.client.Close()
+		t.client = nil
+	}
+}
@@ -0,0 +1,108 @@
+package proxmox
+
+import (
+	"context"
+	"net/url"
+)
+
+// Storage maps the GET /storage/{storage} response data.
+//
+// Credentials are never returned by proxmox.
+type Storage struct {
+	Storage      string `json:"storage"`
+	Type         string `json:"type"`
+	Content      string `json:"content"`
+	Nodes        string `json:"nodes"`
+	Shared       Int    `json:"shared"`
+	Disable      Int    `json:"disable"`
+	PruneBackups string `json:"prune-backups"`
+	Path         string `json:"path"`
+	VGName       string `json:"vgname"`
+	ThinPool     string `json:"thinpool"`
+	Pool         string `json:"pool"`
+	Sparse       Int    `json:"sparse"`
+	Server       string `json:"server"`
+	Export       string `json:"export"`
+	Options      string `json:"options"`
+	Share        string `json:"share"`
+	Domain       string `json:"domain"`
+	SMBVersion   string `json:"smbversion"`
+	Subdir       string `json:"subdir"`
+	Username     string `json:"username"`
+	Datastore    string `json:"datastore"`
+	Namespace    string `json:"namespace"`
+	Fingerprint  string `json:"fingerprint"`
+	MonHost      string `json:"monhost"`
+	FSName       string `json:"fs-name"`
+}
+
+// StorageRequest maps the POST /storage and PUT /storage/{storage}
+// parameters.
+type StorageRequest struct {
+	Storage       string   `url:"storage"`
+	Type          string   `url:"type"`
+	Delete        []string `url:"delete,omitempty"`
+	Content       *string  `url:"content"`
+	Nodes         *string  `url:"nodes"`
+	Shared        *bool    `url:"shared"`
+	Disable       *bool    `url:"disable"`
+	PruneBackups  *string  `url:"prune-backups"`
+	Path          *string  `url:"path"`
+	VGName        *string  `url:"vgname"`
+	ThinPool      *string  `url:"thinpool"`
+	Pool          *string  `url:"pool"`
+	Sparse        *bool    `url:"sparse"`
+	Server        *string  `url:"server"`
+	Export        *string  `url:"export"`
+	Options       *string  `url:"options"`
+	Share         *string  `url:"share"`
+	Domain        *string  `url:"domain"`
+	SMBVersion    *string  `url:"smbversion"`
+	Subdir        *string  `url:"subdir"`
+	Username      *string  `url:"username"`
+	Password      *string  `url:"password"`
+	Datastore     *string  `url:"datastore"`
+	Namespace     *string  `url:"namespace"`
+	Fingerprint   *string  `url:"fingerprint"`
+	EncryptionKey *string  `url:"encryption-key"`
+	MonHost       *string  `url:"monhost"`
+	Keyring       *string  `url:"keyring"`
+	FSName        *string  `url:"fs-name"`
+}
+
+// storageFixedParams are the params that cannot be updated.
+var storageFixedParams = []string{
+	"storage", "type", "path", "vgname", "thinpool", "pool",
+	"server", "export", "share", "datastore", "fs-name",
+}
+
+// GetStorage retrieves the storage config.
+func (c *Client) GetStorage(ctx context.Context, storage string) (*Storage, error) {
+	res := &Storage{}
+	if err := c.Get(ctx, "/storage/"+url.PathEscape(storage), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateStorage creates a storage.
+func (c *Client) CreateStorage(ctx context.Context, req StorageRequest) error {
+	params := EncodeParams(req)
+	params.Del("delete")
+	return c.Post(ctx, "/storage", params, nil)
+}
+
+// UpdateStorage updates a storage, the params that cannot be
+// updated are not sent.
+func (c *Client) UpdateStorage(ctx context.Context, req StorageRequest) error {
+	params := EncodeParams(req)
+	for _, param := range storageFixedParams {
+		params.Del(param)
+	}
+	return c.Put(ctx, "/storage/"+url.PathEscape(req.Storage), params, nil)
+}
+
+// DeleteStorage deletes a storage.
+func (c *Client) DeleteStorage(ctx context.Context, storage string) error {
+	return c.Delete(ctx, "/storage/"+url.PathEscape(storage), nil, nil)
+}
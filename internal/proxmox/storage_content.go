@@ -0,0 +1,124 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// StorageContent maps the
+// GET /nodes/{node}/storage/{storage}/content response data.
+type StorageContent struct {
+	VolID     string `json:"volid"`
+	Content   string `json:"content"`
+	Format    string `json:"format"`
+	Size      int64  `json:"size"`
+	Used      int64  `json:"used"`
+	CTime     int64  `json:"ctime"`
+	Notes     string `json:"notes"`
+	Protected Int    `json:"protected"`
+	VMID      Int    `json:"vmid"`
+}
+
+// DownloadURLRequest maps the
+// POST /nodes/{node}/storage/{storage}/download-url params.
+type DownloadURLRequest struct {
+	Node               string  `url:"-"`
+	Storage            string  `url:"-"`
+	Content            string  `url:"content"`
+	Filename           string  `url:"filename"`
+	URL                string  `url:"url"`
+	Checksum           *string `url:"checksum"`
+	ChecksumAlgorithm  *string `url:"checksum-algorithm"`
+	Compression        *string `url:"compression"`
+	VerifyCertificates *bool   `url:"verify-certificates"`
+}
+
+func storageContentPath(node, storage string) string {
+	return fmt.Sprintf("/nodes/%s/storage/%s/content", url.PathEscape(node), url.PathEscape(storage))
+}
+
+// GetStorageContents lists the volumes of a node storage, content
+// filters the listing by content type when not empty.
+func (c *Client) GetStorageContents(ctx context.Context, node, storage, content string) ([]StorageContent, error) {
+	params := url.Values{}
+	if content != "" {
+		params.Set("content", content)
+	}
+
+	res := []StorageContent{}
+	if err := c.Get(ctx, storageContentPath(node, storage), params, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetStorageVolume looks up a volume within the storage listing,
+// ErrNotFound is returned when the volume does not exist.
+func (c *Client) GetStorageVolume(ctx context.Context, node, storage, volid string) (*StorageContent, error) {
+	contents, err := c.GetStorageContents(ctx, node, storage, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, content := range contents {
+		if content.VolID == volid {
+			return &content, nil
+		}
+	}
+
+	return nil, fmt.Errorf("volume %s: %w", volid, ErrNotFound)
+}
+
+// DeleteStorageVolume deletes a volume and waits for the delete
+// task when proxmox runs one.
+func (c *Client) DeleteStorageVolume(ctx context.Context, node, storage, volid string) error {
+	upid := ""
+	p := storageContentPath(node, storage) + "/" + url.PathEscape(volid)
+	if err := c.Delete(ctx, p, nil, &upid); err != nil {
+		return err
+	}
+
+	if upid == "" {
+		return nil
+	}
+	return c.WaitTask(ctx, upid)
+}
+
+// DownloadURL downloads a file into a node storage and waits
+// for the download task. Proxmox verifies the checksum when set.
+func (c *Client) DownloadURL(ctx context.Context, req DownloadURLRequest) error {
+	upid := ""
+	p := fmt.Sprintf("/nodes/%s/storage/%s/download-url", url.PathEscape(req.Node), url.PathEscape(req.Storage))
+	if err := c.Post(ctx, p, EncodeParams(req), &upid); err != nil {
+		return err
+	}
+	return c.WaitTask(ctx, upid)
+}
+
+// UploadRequest maps the
+// POST /nodes/{node}/storage/{storage}/upload params.
+type UploadRequest struct {
+	Node              string  `url:"-"`
+	Storage           string  `url:"-"`
+	Content           string  `url:"content"`
+	Checksum          *string `url:"checksum"`
+	ChecksumAlgorithm *string `url:"checksum-algorithm"`
+}
+
+// UploadStorageFile streams size bytes from file into a node
+// storage and waits for the upload task. Proxmox verifies the
+// checksum when set.
+func (c *Client) UploadStorageFile(ctx context.Context, req UploadRequest, fileName string, file io.Reader, size int64) error {
+	upid := ""
+	p := fmt.Sprintf("/nodes/%s/storage/%s/upload", url.PathEscape(req.Node), url.PathEscape(req.Storage))
+	if err := c.Upload(ctx, p, EncodeParams(req), fileName, file, size, &upid); err != nil {
+		return err
+	}
+
+	if upid == "" {
+		return nil
+	}
+	return c.WaitTask(ctx, upid)
+}
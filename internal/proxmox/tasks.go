@@ -0,0 +1,74 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// taskPollInterval is the interval between task status checks.
+const taskPollInterval = time.Second
+
+// TaskStatus maps the
+// GET /nodes/{node}/tasks/{upid}/status response data.
+type TaskStatus struct {
+	UPID       string `json:"upid"`
+	Node       string `json:"node"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	ExitStatus string `json:"exitstatus"`
+}
+
+// GetTaskStatus retrieves the status of a node task.
+func (c *Client) GetTaskStatus(ctx context.Context, node, upid string) (*TaskStatus, error) {
+	res := &TaskStatus{}
+	p := fmt.Sprintf("/nodes/%s/tasks/%s/status", url.PathEscape(node), url.PathEscape(upid))
+	if err := c.Get(ctx, p, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WaitTask waits for the task identified by upid to stop. An
+// error is returned when the task does not finish successfully
+// or the context is done.
+func (c *Client) WaitTask(ctx context.Context, upid string) error {
+	node, err := taskNode(upid)
+	if err != nil {
+		return err
+	}
+
+	for {
+		status, err := c.GetTaskStatus(ctx, node, upid)
+		if err != nil {
+			return err
+		}
+
+		if status.Status == "stopped" {
+			// tasks that finish with warnings are considered
+			// successful, i.e. "WARNINGS: 1".
+			if status.ExitStatus == "OK" || strings.HasPrefix(status.ExitStatus, "WARNINGS") {
+				return nil
+			}
+			return fmt.Errorf("task %s failed: %s", upid, status.ExitStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("task %s did not finish: %w", upid, ctx.Err())
+		case <-time.After(taskPollInterval):
+		}
+	}
+}
+
+// taskNode extracts the node name from a task upid, i.e.
+// "UPID:node:pid:pstart:starttime:type:id:user:".
+func taskNode(upid string) (string, error) {
+	parts := strings.Split(upid, ":")
+	if len(parts) < 3 || parts[0] != "UPID" || parts[1] == "" {
+		return "", fmt.Errorf("invalid task upid %q", upid)
+	}
+	return parts[1], nil
+}
@@ -0,0 +1,87 @@
+package proxmox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseFingerprint parses a sha256 certificate fingerprint, either
+// as displayed by proxmox (colon separated) or as plain hex.
+func ParseFingerprint(value string) ([]byte, error) {
+	fingerprint, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(value), ":", ""))
+	if err != nil || len(fingerprint) != sha256.Size {
+		return nil, fmt.Errorf("invalid sha256 fingerprint %q", value)
+	}
+	return fingerprint, nil
+}
+
+// ParseFingerprints parses a comma separated list of sha256
+// certificate fingerprints, one per certificate of the endpoints
+// the provider may fail over to.
+func ParseFingerprints(value string) ([][]byte, error) {
+	var fingerprints [][]byte
+	for _, v := range strings.Split(value, ",") {
+		fingerprint, err := ParseFingerprint(v)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	return fingerprints, nil
+}
+
+// newTLSConfig builds the tls config of the provider api calls.
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+			return nil, errors.New("no certificate found in the ca cert pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// A pinned certificate is trusted on its own, the usual
+	// verification is replaced by the fingerprint comparison. The
+	// endpoints share the tls config, a certificate matching any of
+	// the pinned fingerprints is trusted so failover works with a
+	// self-signed certificate per node.
+	if cfg.TLSFingerprintSHA256 != "" {
+		fingerprints, err := ParseFingerprints(cfg.TLSFingerprintSHA256)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("no server certificate to verify the fingerprint of")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			for _, fingerprint := range fingerprints {
+				if bytes.Equal(sum[:], fingerprint) {
+					return nil
+				}
+			}
+			return fmt.Errorf("server certificate fingerprint %X does not match a pinned one", sum)
+		}
+	}
+
+	if cfg.ClientCertPEM != "" || cfg.ClientKeyPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCertPEM), []byte(cfg.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
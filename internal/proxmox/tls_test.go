@@ -0,0 +1,87 @@
+package proxmox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseFingerprint(t *testing.T) {
+	const plain = "5ad3a1d4e1f2c3b4a5968778695a4b3c2d1e0f00112233445566778899aabbcc"
+	want, _ := hex.DecodeString(plain)
+
+	colons := ""
+	for i := 0; i < len(plain); i += 2 {
+		if i > 0 {
+			colons += ":"
+		}
+		colons += plain[i : i+2]
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "plain hex", value: plain},
+		{name: "colon separated", value: colons},
+		{name: "upper case", value: "5AD3A1D4E1F2C3B4A5968778695A4B3C2D1E0F00112233445566778899AABBCC"},
+		{name: "surrounding spaces", value: " " + colons + "\n"},
+		{name: "too short", value: plain[:62], wantErr: true},
+		{name: "too long", value: plain + "00", wantErr: true},
+		{name: "sha1 length", value: plain[:40], wantErr: true},
+		{name: "not hex", value: "zz" + plain[2:], wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFingerprint(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFingerprint(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, want) {
+				t.Errorf("ParseFingerprint(%q) = %x, want %x", tt.value, got, want)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfigFingerprints(t *testing.T) {
+	node1 := []byte("node1 certificate")
+	node2 := []byte("node2 certificate")
+	other := []byte("other certificate")
+	sum1 := sha256.Sum256(node1)
+	sum2 := sha256.Sum256(node2)
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		cert        []byte
+		wantErr     bool
+	}{
+		{name: "single match", fingerprint: hex.EncodeToString(sum1[:]), cert: node1},
+		{name: "single mismatch", fingerprint: hex.EncodeToString(sum1[:]), cert: node2, wantErr: true},
+		{name: "list first", fingerprint: hex.EncodeToString(sum1[:]) + ", " + hex.EncodeToString(sum2[:]), cert: node1},
+		{name: "list second", fingerprint: hex.EncodeToString(sum1[:]) + ", " + hex.EncodeToString(sum2[:]), cert: node2},
+		{name: "list mismatch", fingerprint: hex.EncodeToString(sum1[:]) + "," + hex.EncodeToString(sum2[:]), cert: other, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := newTLSConfig(Config{TLSFingerprintSHA256: tt.fingerprint})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tlsConfig.VerifyPeerCertificate([][]byte{tt.cert}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyPeerCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := newTLSConfig(Config{TLSFingerprintSHA256: hex.EncodeToString(sum1[:]) + ","}); err == nil {
+		t.Error("newTLSConfig() with an empty fingerprint in the list succeeded")
+	}
+}
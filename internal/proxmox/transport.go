@@ -0,0 +1,133 @@
+package proxmox
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// dialFunc dials the api through a proxy or a tunnel.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// newDialer returns the dial func of the configured proxy or
+// tunnel, nil when the api is dialed directly.
+func newDialer(cfg Config) (dialFunc, error) {
+	set := 0
+	for _, ok := range []bool{cfg.HTTPProxy != "", cfg.SOCKS5Proxy != "", cfg.SSHTunnel != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, errors.New("only one of the http proxy, socks5 proxy and ssh tunnel can be set")
+	}
+
+	switch {
+	case cfg.HTTPProxy != "":
+		return httpProxyDialer(cfg.HTTPProxy)
+	case cfg.SOCKS5Proxy != "":
+		return socks5Dialer(cfg.SOCKS5Proxy)
+	case cfg.SSHTunnel != nil:
+		return sshTunnelDialer(*cfg.SSHTunnel)
+	}
+	return nil, nil
+}
+
+// parseProxyURL parses a proxy url, scheme is assumed when missing.
+func parseProxyURL(value, scheme string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		u, err = url.Parse(scheme + "://" + value)
+	}
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid %s proxy %q", scheme, value)
+	}
+	return u, nil
+}
+
+// httpProxyDialer dials the api through an http proxy CONNECT
+// tunnel, so the tls session stays end to end.
+func httpProxyDialer(value string) (dialFunc, error) {
+	u, err := parseProxyURL(value, "http")
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("invalid http proxy %q, only http:// proxies are supported", value)
+	}
+	proxyAddr := u.Host
+	if u.Port() == "" {
+		proxyAddr = net.JoinHostPort(u.Hostname(), "80")
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, proxyAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: addr},
+			Host:   addr,
+			Header: http.Header{},
+		}
+		if u.User != nil {
+			password, _ := u.User.Password()
+			credentials := base64.StdEncoding.EncodeToString([]byte(u.User.Username() + ":" + password))
+			req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+			defer conn.SetDeadline(time.Time{})
+		}
+		if err := req.Write(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		res, err := http.ReadResponse(bufio.NewReader(conn), req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, fmt.Errorf("http proxy CONNECT %s: %s", addr, res.Status)
+		}
+
+		return conn, nil
+	}, nil
+}
+
+// socks5Dialer dials the api through a socks5 proxy.
+func socks5Dialer(value string) (dialFunc, error) {
+	u, err := parseProxyURL(value, "socks5")
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "socks5" && u.Scheme != "socks5h" {
+		return nil, fmt.Errorf("invalid socks5 proxy %q", value)
+	}
+
+	var auth *proxy.Auth
+	if u.User != nil {
+		password, _ := u.User.Password()
+		auth = &proxy.Auth{User: u.User.Username(), Password: password}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", u.Host, auth, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.(proxy.ContextDialer).DialContext, nil
+}
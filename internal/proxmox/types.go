@@ -0,0 +1,33 @@
+package proxmox
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Int is an integer that proxmox might return either as a
+// json number or as a json string (i.e. values read from
+// config files such as /etc/network/interfaces).
+type Int int
+
+func (i *Int) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	if len(b) == 0 || string(b) == "null" {
+		*i = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(string(b))
+	if err != nil {
+		return err
+	}
+	*i = Int(n)
+
+	return nil
+}
+
+// MarshalJSON keeps the value as a json number.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(i))
+}
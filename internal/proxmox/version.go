@@ -0,0 +1,21 @@
+package proxmox
+
+import "context"
+
+// Version maps the GET /version response data.
+type Version struct {
+	Release string `json:"release"`
+	Version string `json:"version"`
+	RepoID  string `json:"repoid"`
+}
+
+// GetVersion retrieves the api version. It overrides the
+// go-proxmox one so the version is read with the provider
+// authentication.
+func (c *Client) GetVersion(ctx context.Context) (*Version, error) {
+	res := &Version{}
+	if err := c.Get(ctx, "/version", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,127 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// VMIDMin and VMIDMax are the bounds of the vmids accepted
+	// by proxmox.
+	VMIDMin = 100
+	VMIDMax = 999999999
+
+	// vmidAllocationAttempts is the number of vmids tried by
+	// AllocateVMID when the api reports a collision.
+	vmidAllocationAttempts = 5
+)
+
+// VMIDRange bounds the vmids allocated by the provider.
+type VMIDRange struct {
+	Min int
+	Max int
+}
+
+// DefaultVMIDRange is the range used when none is configured.
+var DefaultVMIDRange = VMIDRange{Min: VMIDMin, Max: VMIDMax}
+
+// Validate ensures the range is within the proxmox vmid bounds.
+func (r VMIDRange) Validate() error {
+	switch {
+	case r.Min < VMIDMin:
+		return fmt.Errorf("min vmid %d is lower than %d", r.Min, VMIDMin)
+	case r.Max > VMIDMax:
+		return fmt.Errorf("max vmid %d is greater than %d", r.Max, VMIDMax)
+	case r.Min > r.Max:
+		return fmt.Errorf("min vmid %d is greater than max vmid %d", r.Min, r.Max)
+	}
+	return nil
+}
+
+// VMIDRange returns the vmid range configured for the client.
+func (c *Client) VMIDRange() VMIDRange {
+	return c.vmidRange
+}
+
+// NextVMID returns the lowest vmid within r that is not used by
+// any guest of the cluster. The vmid is not reserved, use
+// AllocateVMID when a guest is created with it.
+func (c *Client) NextVMID(ctx context.Context, r VMIDRange) (int, error) {
+	return c.nextVMID(ctx, r)
+}
+
+// AllocateVMID picks a free vmid within the client range and calls
+// create with it. Allocations are serialized within the provider,
+// the vmid stays reserved until create returns so concurrent
+// creations never pick the same one. When create fails because
+// the vmid already exists (i.e. a guest created out of terraform)
+// the next free vmid is tried.
+func (c *Client) AllocateVMID(ctx context.Context, create func(vmid int) error) (int, error) {
+	skip := map[int]bool{}
+	for attempt := 1; ; attempt++ {
+		vmid, err := c.reserveVMID(ctx, skip)
+		if err != nil {
+			return 0, err
+		}
+
+		err = create(vmid)
+		c.releaseVMID(vmid)
+		if err == nil {
+			return vmid, nil
+		}
+		if !IsVMIDCollision(err) || attempt == vmidAllocationAttempts {
+			return 0, err
+		}
+		skip[vmid] = true
+	}
+}
+
+// IsVMIDCollision reports whether err is caused by the creation of
+// a guest with a vmid that is already in use.
+func IsVMIDCollision(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "already exists")
+}
+
+func (c *Client) reserveVMID(ctx context.Context, skip map[int]bool) (int, error) {
+	c.vmidMu.Lock()
+	defer c.vmidMu.Unlock()
+
+	vmid, err := c.nextVMID(ctx, c.vmidRange, c.vmidReserved, skip)
+	if err != nil {
+		return 0, err
+	}
+
+	c.vmidReserved[vmid] = true
+	return vmid, nil
+}
+
+func (c *Client) releaseVMID(vmid int) {
+	c.vmidMu.Lock()
+	defer c.vmidMu.Unlock()
+
+	delete(c.vmidReserved, vmid)
+}
+
+// nextVMID returns the lowest vmid within r that is neither used
+// by a guest nor within any of the skip sets.
+func (c *Client) nextVMID(ctx context.Context, r VMIDRange, skip ...map[int]bool) (int, error) {
+	guests, err := c.GetClusterResources(ctx, "vm")
+	if err != nil {
+		return 0, err
+	}
+
+	used := map[int]bool{}
+	for _, g := range guests {
+		used[int(g.VMID)] = true
+	}
+
+	skip = append(skip, used)
+	for vmid := r.Min; vmid <= r.Max; vmid++ {
+		if !slices.ContainsFunc(skip, func(s map[int]bool) bool { return s[vmid] }) {
+			return vmid, nil
+		}
+	}
+	return 0, fmt.Errorf("no free vmid within %d-%d", r.Min, r.Max)
+}